@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+var bridgeStatusCmd = &cobra.Command{
+	Use:   "bridge-status",
+	Short: "Show the active evmbridge params",
+	Long:  "Show the bridge workers and bridge contract address active on chain, optionally checking them against EXPECT_BRIDGE_WORKERS and EXPECT_BRIDGE_CONTRACT",
+	Args:  cobra.NoArgs,
+	Run:   runBridgeStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(bridgeStatusCmd)
+}
+
+func runBridgeStatus(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	if err := junctiontest.CheckJunctiond(&config); err != nil {
+		exitWithError("Error", err)
+	}
+	params, err := junctiontest.QueryBridgeParams(&config)
+	if err != nil {
+		exitWithError("Error", err)
+	}
+
+	fmt.Println("🌉 EVM bridge params:")
+	fmt.Printf("   Bridge contract: %s\n", params.BridgeContractAddress)
+	fmt.Printf("   Bridge workers (%d):\n", len(params.BridgeWorkers))
+	for _, worker := range params.BridgeWorkers {
+		fmt.Printf("     - %s\n", worker)
+	}
+
+	if len(config.ExpectBridgeWorkers) == 0 && config.ExpectBridgeContract == "" {
+		return
+	}
+	if err := junctiontest.CheckBridgeParams(params, config.ExpectBridgeWorkers, config.ExpectBridgeContract); err != nil {
+		exitWithError("Error", err)
+	}
+	fmt.Println("✅ Bridge params match expectations")
+}
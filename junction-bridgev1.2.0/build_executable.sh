@@ -6,9 +6,15 @@ echo "🔨 Building Junction Bridge Testing Tool..."
 # Create build directory if it doesn't exist
 mkdir -p build
 
+# Collect build metadata
+VERSION=$(git describe --tags --always --dirty 2>/dev/null || echo "dev")
+COMMIT=$(git rev-parse --short HEAD 2>/dev/null || echo "unknown")
+BUILD_DATE=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+LDFLAGS="-X main.version=${VERSION} -X main.commit=${COMMIT} -X main.buildDate=${BUILD_DATE}"
+
 # Build the main executable
-echo "📦 Building main executable..."
-go build -o build/junction-bridge main.go
+echo "📦 Building main executable (version ${VERSION})..."
+go build -ldflags "${LDFLAGS}" -o build/junction-bridge .
 
 # Make it executable
 chmod +x build/junction-bridge
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel [proposal-id]",
+	Short: "Cancel a pending proposal",
+	Long:  "Withdraw a proposal still in its deposit or voting period with `junctiond tx gov cancel-proposal`, from key_name, which must be the proposer",
+	Args:  cobra.ExactArgs(1),
+	Run:   runCancel,
+}
+
+func init() {
+	rootCmd.AddCommand(cancelCmd)
+}
+
+func runCancel(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	proposalID := args[0]
+	if err := junctiontest.CheckJunctiond(&config); err != nil {
+		exitWithError("Error", err)
+	}
+
+	fmt.Printf("🗑️  Cancelling proposal %s...\n", proposalID)
+	txResponse, err := junctiontest.CancelProposal(&config, proposalID)
+	if err != nil {
+		exitWithError("Error cancelling proposal", err)
+	}
+	if _, err := junctiontest.WaitForTx(&config, txResponse.TxHash, 30*time.Second); err != nil {
+		exitWithError("Error cancelling proposal", err)
+	}
+
+	fmt.Printf("✅ Proposal %s cancelled\n", proposalID)
+	updateState(func(state *TestingState) {
+		if state.ProposalID == proposalID {
+			state.Phase = phaseFinished
+			state.Outcome = "cancelled"
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"junction-bridge/junctiontest"
+)
+
+// cachedCID returns the CID cached for the current content of metadataPath.
+// An explicit IPFS_CID always wins, so the cache is only consulted without
+// one; a cache that cannot be read is ignored with a warning.
+func cachedCID(metadataPath string) (string, bool) {
+	if config.IPFSCID != "" || config.CIDCacheFile == "" {
+		return "", false
+	}
+	cache, err := junctiontest.LoadCIDCache(config.CIDCacheFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring the CID cache: %v\n", err)
+		return "", false
+	}
+	cid, ok, err := cache.Lookup(metadataPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring the CID cache: %v\n", err)
+		return "", false
+	}
+	return cid, ok
+}
+
+// cacheCID records cid for the current content of metadataPath. Failing to
+// update the cache only costs a prompt next time, so it is a warning.
+func cacheCID(metadataPath, cid string) {
+	if config.CIDCacheFile == "" {
+		return
+	}
+	cache, err := junctiontest.LoadCIDCache(config.CIDCacheFile)
+	if err != nil {
+		cache = junctiontest.CIDCache{}
+	}
+	if err := cache.Store(metadataPath, cid); err == nil {
+		err = cache.Save(config.CIDCacheFile)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not update the CID cache: %v\n", err)
+	}
+}
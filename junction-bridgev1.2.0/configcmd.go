@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show the effective configuration and where each value came from",
+	Long:  "Print every config key's resolved value as JSON with its source (flag, env, file or default), with secrets redacted",
+	Args:  cobra.NoArgs,
+	Run:   runConfig,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+}
+
+// boundFlags maps config keys to the flags bound to them with bindFlag.
+var boundFlags = map[string]*pflag.Flag{}
+
+// bindFlag binds flag to the config key and remembers the binding so the
+// config command can tell when a value came from the command line.
+func bindFlag(key string, flag *pflag.Flag) {
+	boundFlags[key] = flag
+	viper.BindPFlag(key, flag)
+}
+
+// redactedKeys are config keys whose values are secrets.
+var redactedKeys = map[string]bool{
+	"key_mnemonic": true,
+	"webhook_url":  true,
+}
+
+type configValue struct {
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+func runConfig(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	known := chainConfigKeys(reflect.TypeOf(junctiontest.ChainConfig{}))
+	known["exit_codes"] = true
+
+	values := map[string]configValue{}
+	for _, key := range viper.AllKeys() {
+		if !known[strings.SplitN(key, ".", 2)[0]] {
+			continue
+		}
+		value := viper.Get(key)
+		if redactedKeys[key] && fmt.Sprint(value) != "" {
+			value = "<redacted>"
+		}
+		values[key] = configValue{Value: value, Source: configSource(key)}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(values); err != nil {
+		exitWithError("Error", err)
+	}
+}
+
+// configSource reports where viper took key's value from, in viper's order of
+// precedence.
+func configSource(key string) string {
+	if flag, ok := boundFlags[key]; ok && flag.Changed {
+		return "flag"
+	}
+	if _, ok := os.LookupEnv(strings.ToUpper(key)); ok {
+		return "env"
+	}
+	if viper.InConfig(key) {
+		return "file"
+	}
+	return "default"
+}
+
+// chainConfigKeys returns the mapstructure keys of t's fields, including
+// those of squashed embedded structs.
+func chainConfigKeys(t reflect.Type) map[string]bool {
+	keys := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if opts == "squash" {
+			for key := range chainConfigKeys(field.Type) {
+				keys[key] = true
+			}
+			continue
+		}
+		if name != "" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/viper"
+)
+
+// Exit codes per failure category. Infrastructure failures (10-29) are worth
+// retrying in CI; proposal and tx failures (30+) are not.
+var exitCodes = map[string]int{
+	"missing_dependency":      10,
+	"version_mismatch":        11,
+	"config_drift":            12,
+	"checksum_mismatch":       13,
+	"chain_not_ready":         20,
+	"proposal_rejected":       30,
+	"deposit_too_low":         31,
+	"invalid_address":         32,
+	"insufficient_balance":    33,
+	"validator_set_changed":   34,
+	"expectation_failed":      35,
+	"proposal_not_cancelable": 36,
+	"tx_failed":               40,
+}
+
+// exitCategories maps each category to its sentinel error, most specific
+// first since ErrDepositTooLow and ErrInvalidAddress also wrap ErrTxFailed.
+var exitCategories = []struct {
+	name string
+	err  error
+}{
+	{"missing_dependency", junctiontest.ErrMissingDependency},
+	{"version_mismatch", junctiontest.ErrVersionMismatch},
+	{"config_drift", junctiontest.ErrConfigDrift},
+	{"checksum_mismatch", junctiontest.ErrChecksumMismatch},
+	{"chain_not_ready", junctiontest.ErrChainNotReady},
+	{"expectation_failed", junctiontest.ErrExpectationFailed},
+	{"proposal_rejected", junctiontest.ErrProposalRejected},
+	{"deposit_too_low", junctiontest.ErrDepositTooLow},
+	{"invalid_address", junctiontest.ErrInvalidAddress},
+	{"insufficient_balance", junctiontest.ErrInsufficientBalance},
+	{"validator_set_changed", junctiontest.ErrValidatorSetChanged},
+	{"proposal_not_cancelable", junctiontest.ErrProposalNotCancelable},
+	{"tx_failed", junctiontest.ErrTxFailed},
+}
+
+// loadExitCodes applies any overrides from the exit_codes config section.
+func loadExitCodes() {
+	overrides := map[string]int{}
+	if err := viper.UnmarshalKey("exit_codes", &overrides); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring invalid exit_codes config: %v\n", err)
+		return
+	}
+	for name, code := range overrides {
+		if _, ok := exitCodes[name]; !ok {
+			fmt.Fprintf(os.Stderr, "Warning: unknown exit code category %q\n", name)
+			continue
+		}
+		exitCodes[name] = code
+	}
+}
+
+// exitCode returns the process exit code for err, or 1 if it does not match
+// any category.
+func exitCode(err error) int {
+	for _, category := range exitCategories {
+		if errors.Is(err, category.err) {
+			return exitCodes[category.name]
+		}
+	}
+	return 1
+}
+
+// exitWithError prints err to stderr and exits with its category's code.
+// Processes this run started in the background, such as a SINGLE_PROCESS
+// chain, are stopped first so they are not left behind.
+func exitWithError(prefix string, err error) {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", prefix, err)
+	junctiontest.Processes.StopAll()
+	os.Exit(exitCode(err))
+}
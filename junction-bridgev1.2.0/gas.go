@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+const gasProfileFile = "gas_profile.json"
+
+// startGasProfile loads the persistent gas profile as junctiontest.Gas, so
+// the submit, deposit and vote txs of this run are recorded in it. Failures
+// are only warnings and leave the run unprofiled.
+func startGasProfile() {
+	profiler, err := junctiontest.LoadGasProfiler(gasProfileFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	junctiontest.Gas = profiler
+}
+
+// waitForTx waits for txHash through junctiontest.Gas, recording its gas
+// under operation, and saves the profile so the sample survives an early
+// exit.
+func waitForTx(ctx context.Context, operation, txHash string) (*junctiontest.TxResult, error) {
+	result, err := junctiontest.Gas.WaitForTxContext(ctx, &config, operation, txHash, 30*time.Second)
+	if result != nil {
+		fmt.Printf("⛽ Gas used: %d (wanted %d)\n", result.GasUsed, result.GasWanted)
+		saveGasProfile()
+	}
+	return result, err
+}
+
+// saveGasProfile writes junctiontest.Gas back to gasProfileFile. Failures
+// are only warnings since the txs themselves are unaffected.
+func saveGasProfile() {
+	if junctiontest.Gas == nil {
+		return
+	}
+	if err := junctiontest.Gas.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+func runSimulateProposal(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	if err := junctiontest.CheckJunctiond(&config); err != nil {
+		exitWithError("Error", err)
+	}
+	fmt.Printf("🧪 Simulating %s...\n", args[0])
+	gas, err := junctiontest.SimulateProposalFile(&config, args[0])
+	if err != nil {
+		exitWithError("Simulation failed", err)
+	}
+	fmt.Printf("✅ Simulation passed, estimated gas: %d\n", gas)
+}
+
+func runGasReport(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	profiler, err := junctiontest.LoadGasProfiler(gasProfileFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading gas profile: %v\n", err)
+		os.Exit(1)
+	}
+	profiler.PrintReport()
+}
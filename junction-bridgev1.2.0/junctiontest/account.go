@@ -0,0 +1,60 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// FetchAccountInfo returns the account number and sequence of address from
+// `junctiond query auth account`, as needed to sign a tx offline. It reads
+// both the proto JSON ("account_number" on the account) and the amino JSON
+// ("value" wrapping it) forms, and the base account inside vesting accounts.
+func FetchAccountInfo(cfg *ChainConfig, address string) (accountNumber, sequence uint64, err error) {
+	out, err := JunctiondCommand(cfg, "query", "auth", "account", address, "--output", "json").Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("error querying account %s: %v", address, err)
+	}
+	var response struct {
+		Account map[string]json.RawMessage `json:"account"`
+	}
+	if err := json.Unmarshal(out, &response); err != nil {
+		return 0, 0, fmt.Errorf("error parsing account %s: %v", address, err)
+	}
+
+	fields := response.Account
+	for _, wrapper := range []string{"value", "base_vesting_account", "base_account"} {
+		var inner map[string]json.RawMessage
+		if raw, ok := fields[wrapper]; ok && json.Unmarshal(raw, &inner) == nil {
+			fields = inner
+		}
+	}
+
+	accountNumber, err = accountUint(fields, "account_number")
+	if err != nil {
+		return 0, 0, fmt.Errorf("account %s: %v", address, err)
+	}
+	sequence, err = accountUint(fields, "sequence")
+	if err != nil {
+		return 0, 0, fmt.Errorf("account %s: %v", address, err)
+	}
+	return accountNumber, sequence, nil
+}
+
+// accountUint reads a uint64 field that may be encoded as a string (proto
+// JSON) or a number. A missing field is zero, as the JSON omits zero values.
+func accountUint(fields map[string]json.RawMessage, key string) (uint64, error) {
+	raw, ok := fields[key]
+	if !ok {
+		return 0, nil
+	}
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		s = string(raw)
+	}
+	value, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s", key, raw)
+	}
+	return value, nil
+}
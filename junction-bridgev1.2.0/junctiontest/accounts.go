@@ -0,0 +1,101 @@
+package junctiontest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ExtraAccount is an additional key funded at genesis, such as a faucet or a
+// delegator.
+type ExtraAccount struct {
+	Name   string
+	Amount string
+}
+
+// ParseExtraAccounts parses "name:amount" entries such as
+// "faucet:1000000000uamf".
+func ParseExtraAccounts(specs []string) ([]ExtraAccount, error) {
+	var accounts []ExtraAccount
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		name, amount, ok := strings.Cut(spec, ":")
+		name, amount = strings.TrimSpace(name), strings.TrimSpace(amount)
+		if !ok || name == "" || amount == "" {
+			return nil, fmt.Errorf("invalid extra account %q (expected name:amount)", spec)
+		}
+		if _, err := ParseCoins(amount); err != nil {
+			return nil, fmt.Errorf("invalid extra account %q: %v", spec, err)
+		}
+		accounts = append(accounts, ExtraAccount{Name: name, Amount: amount})
+	}
+	return accounts, nil
+}
+
+// addGenesisAccount funds keyName with amount in the genesis at homeDir,
+// leaving an account that is already there untouched.
+func addGenesisAccount(cfg *ChainConfig, homeDir, keyName, amount string) error {
+	address, err := KeyAddress(cfg, keyName)
+	if err != nil {
+		return fmt.Errorf("error looking up key address: %v", err)
+	}
+	exists, err := GenesisHasAccount(homeDir, address)
+	if err != nil {
+		return fmt.Errorf("error reading genesis accounts: %v", err)
+	}
+	if exists {
+		fmt.Printf("✅ Genesis account already exists: %s\n", address)
+		return nil
+	}
+
+	genesisAccountCmd := JunctiondCommand(cfg, "genesis", "add-genesis-account", keyName, amount, "--keyring-backend", "os")
+	if err := RunCommand(genesisAccountCmd); err != nil {
+		return fmt.Errorf("error adding genesis account %s: %v", keyName, err)
+	}
+	return nil
+}
+
+// addExtraAccounts creates the keys for accounts, up to cfg.KeyWorkers at a
+// time, then funds each in the genesis at homeDir. add-genesis-account
+// rewrites genesis.json, so the funding runs one account at a time. Every
+// failure is reported, joined into one error.
+func addExtraAccounts(cfg *ChainConfig, homeDir string, accounts []ExtraAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+	workers := cfg.KeyWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	fmt.Printf("\n🔑 Creating %d extra account key(s), %d at a time...\n", len(accounts), workers)
+	keyErrs := make([]error, len(accounts))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, account := range accounts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			keyErrs[i] = EnsureKey(cfg, name)
+		}(i, account.Name)
+	}
+	wg.Wait()
+	if err := errors.Join(keyErrs...); err != nil {
+		return err
+	}
+
+	var fundErrs []error
+	for _, account := range accounts {
+		fmt.Printf("\n💰 Adding extra genesis account %s (%s)...\n", account.Name, account.Amount)
+		if err := addGenesisAccount(cfg, homeDir, account.Name, account.Amount); err != nil {
+			fundErrs = append(fundErrs, err)
+		}
+	}
+	return errors.Join(fundErrs...)
+}
@@ -0,0 +1,44 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// OutputFileMode is the permission mode of the files the framework writes
+// for the user: state, metadata, proposal, report and profile files.
+var OutputFileMode os.FileMode = 0644
+
+// WriteFileAtomic writes data to path with OutputFileMode. The data goes to a
+// temporary file in the same directory that is then renamed over path, so a
+// crash mid-write leaves either the old file or the new one, never a
+// truncated one.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(OutputFileMode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("error replacing %s: %v", path, err)
+	}
+	return nil
+}
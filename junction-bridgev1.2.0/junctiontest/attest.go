@@ -0,0 +1,177 @@
+package junctiontest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// AttestationChainID is the chain ID proposal attestations are signed for.
+// No real chain uses it, so an attestation can never be broadcast as a tx.
+const AttestationChainID = "proposal-attestation"
+
+// ProposalHash returns the hex SHA-256 of the proposal file at proposalPath
+// with its metadata field left out, since the metadata the field points to
+// is where the author's signature over this hash is kept. Keys are hashed in
+// sorted order, so reformatting the file does not change the hash.
+func ProposalHash(proposalPath string) (string, error) {
+	data, err := os.ReadFile(proposalPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading proposal file: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("error parsing proposal file: %v", err)
+	}
+	delete(fields, "metadata")
+
+	canonical, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("error encoding proposal: %v", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SignProposalHash signs the ProposalHash of proposalPath with keyName from
+// the os keyring, returning the base64 signature and public key. junctiond
+// has no command to sign arbitrary data, so the hash is signed as the memo
+// of an offline tx for AttestationChainID that is never broadcast.
+func SignProposalHash(cfg *ChainConfig, keyName, proposalPath string) (signature string, pubKey string, err error) {
+	hash, err := ProposalHash(proposalPath)
+	if err != nil {
+		return "", "", err
+	}
+	address, err := KeyAddress(cfg, keyName)
+	if err != nil {
+		return "", "", fmt.Errorf("error looking up %s address: %v", keyName, err)
+	}
+
+	dir, err := os.MkdirTemp("", "proposal-attestation")
+	if err != nil {
+		return "", "", fmt.Errorf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	unsignedPath, err := writeAttestationTx(cfg, dir, address, hash)
+	if err != nil {
+		return "", "", err
+	}
+
+	signArgs := append([]string{"tx", "sign", unsignedPath, "--from", keyName, "--keyring-backend", "os"}, attestationSignerFlags...)
+	out, err := JunctiondCommand(cfg, signArgs...).Output()
+	if err != nil {
+		return "", "", fmt.Errorf("error signing proposal hash: %v", err)
+	}
+	var signed struct {
+		AuthInfo struct {
+			SignerInfos []struct {
+				PublicKey struct {
+					Key string `json:"key"`
+				} `json:"public_key"`
+			} `json:"signer_infos"`
+		} `json:"auth_info"`
+		Signatures []string `json:"signatures"`
+	}
+	if err := json.Unmarshal(out, &signed); err != nil {
+		return "", "", fmt.Errorf("error parsing signed attestation: %v", err)
+	}
+	if len(signed.Signatures) != 1 || len(signed.AuthInfo.SignerInfos) != 1 {
+		return "", "", fmt.Errorf("signed attestation has %d signatures, expected 1", len(signed.Signatures))
+	}
+	return signed.Signatures[0], signed.AuthInfo.SignerInfos[0].PublicKey.Key, nil
+}
+
+// VerifyProposalIntegrity checks metadata's author signature against the
+// proposal file at proposalPath: the attestation tx is rebuilt from the
+// proposal's current hash and the signature is validated by junctiond, so
+// any change to the proposal since it was signed fails the check.
+func VerifyProposalIntegrity(cfg *ChainConfig, metadata *ProposalMetadata, proposalPath string) error {
+	if metadata.AuthorSignature == "" {
+		return fmt.Errorf("metadata has no author signature")
+	}
+	hash, err := ProposalHash(proposalPath)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "proposal-attestation")
+	if err != nil {
+		return fmt.Errorf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	unsignedPath, err := writeAttestationTx(cfg, dir, metadata.AuthorAddress, hash)
+	if err != nil {
+		return err
+	}
+
+	// Attach the stored signature to the rebuilt tx
+	data, err := os.ReadFile(unsignedPath)
+	if err != nil {
+		return fmt.Errorf("error reading attestation tx: %v", err)
+	}
+	var tx map[string]interface{}
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return fmt.Errorf("error parsing attestation tx: %v", err)
+	}
+	authInfo, _ := tx["auth_info"].(map[string]interface{})
+	if authInfo == nil {
+		return fmt.Errorf("attestation tx has no auth_info")
+	}
+	authInfo["signer_infos"] = []interface{}{map[string]interface{}{
+		"public_key": map[string]interface{}{
+			"@type": "/cosmos.crypto.secp256k1.PubKey",
+			"key":   metadata.AuthorPubKey,
+		},
+		"mode_info": map[string]interface{}{
+			"single": map[string]interface{}{"mode": "SIGN_MODE_DIRECT"},
+		},
+		"sequence": "0",
+	}}
+	tx["signatures"] = []interface{}{metadata.AuthorSignature}
+	signedPath := filepath.Join(dir, "signed.json")
+	data, err = json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("error encoding attestation tx: %v", err)
+	}
+	if err := os.WriteFile(signedPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing attestation tx: %v", err)
+	}
+
+	validateArgs := append([]string{"tx", "validate-signatures", signedPath}, attestationSignerFlags...)
+	if out, err := JunctiondCommand(cfg, validateArgs...).CombinedOutput(); err != nil {
+		return fmt.Errorf("author signature does not match %s (signed by %s): %v: %s", proposalPath, metadata.AuthorAddress, err, out)
+	}
+	return nil
+}
+
+// attestationSignerFlags sign and validate attestations without a node.
+var attestationSignerFlags = []string{
+	"--chain-id", AttestationChainID,
+	"--offline",
+	"--account-number", "0",
+	"--sequence", "0",
+}
+
+// writeAttestationTx writes the unsigned attestation tx for hash, a 1 unit
+// self-send from address carrying the hash as its memo, to dir and returns
+// its path. The same inputs always produce the same tx.
+func writeAttestationTx(cfg *ChainConfig, dir, address, hash string) (string, error) {
+	generateArgs := append([]string{
+		"tx", "bank", "send", address, address, "1" + cfg.Denom,
+		"--note", hash,
+		"--gas", "200000",
+		"--generate-only",
+	}, attestationSignerFlags...)
+	out, err := JunctiondCommand(cfg, generateArgs...).Output()
+	if err != nil {
+		return "", fmt.Errorf("error building attestation tx: %v", err)
+	}
+	path := filepath.Join(dir, "unsigned.json")
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		return "", fmt.Errorf("error writing attestation tx: %v", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,35 @@
+package junctiontest
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff is an exponential backoff between polls: the pause doubles from
+// Initial up to Max, and each pause is randomized between half and all of
+// that, so chains started together do not poll in lockstep.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Delay returns the pause before poll attempt+1, counting from 0.
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 0; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max && b.Max >= b.Initial {
+		d = b.Max
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// ReadyBackoff is the backoff used while waiting for a node to start and
+// sync, from ReadyPollInitial and ReadyPollMax.
+func (c *ChainConfig) ReadyBackoff() Backoff {
+	return Backoff{Initial: c.ReadyPollInitial, Max: c.ReadyPollMax}
+}
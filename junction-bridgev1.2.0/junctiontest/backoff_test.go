@@ -0,0 +1,34 @@
+package junctiontest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		attempt int
+		base    time.Duration
+	}{
+		{"first attempt", Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second}, 0, 500 * time.Millisecond},
+		{"doubles", Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second}, 3, 4 * time.Second},
+		{"capped at max", Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second}, 5, 10 * time.Second},
+		{"many attempts stay capped", Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second}, 1000, 10 * time.Second},
+		{"constant", Backoff{Initial: time.Second, Max: time.Second}, 7, time.Second},
+		{"max below initial keeps initial", Backoff{Initial: time.Second, Max: 0}, 3, time.Second},
+		{"zero", Backoff{}, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Jitter puts each delay between half and all of the base
+			for i := 0; i < 50; i++ {
+				got := tt.backoff.Delay(tt.attempt)
+				if got < tt.base/2 || got > tt.base {
+					t.Fatalf("Delay(%d) = %s, want between %s and %s", tt.attempt, got, tt.base/2, tt.base)
+				}
+			}
+		})
+	}
+}
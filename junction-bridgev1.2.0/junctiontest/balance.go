@@ -0,0 +1,112 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var coinPattern = regexp.MustCompile(`^([0-9]+)([a-zA-Z][a-zA-Z0-9/:._-]*)$`)
+
+// ParseCoins parses a comma-separated coin list such as "500uamf,10utest"
+// into amounts per denom.
+func ParseCoins(coins string) (map[string]*big.Int, error) {
+	result := map[string]*big.Int{}
+	for _, coin := range strings.Split(coins, ",") {
+		coin = strings.TrimSpace(coin)
+		if coin == "" {
+			continue
+		}
+		match := coinPattern.FindStringSubmatch(coin)
+		if match == nil {
+			return nil, fmt.Errorf("invalid coin %q", coin)
+		}
+		amount, _ := new(big.Int).SetString(match[1], 10)
+		if existing, ok := result[match[2]]; ok {
+			existing.Add(existing, amount)
+		} else {
+			result[match[2]] = amount
+		}
+	}
+	return result, nil
+}
+
+// FormatCoins renders amounts per denom as a coin list such as
+// "500uamf,10utest", sorted by denom.
+func FormatCoins(coins map[string]*big.Int) string {
+	denoms := make([]string, 0, len(coins))
+	for denom := range coins {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	parts := make([]string, len(denoms))
+	for i, denom := range denoms {
+		parts[i] = coins[denom].String() + denom
+	}
+	return strings.Join(parts, ",")
+}
+
+// QueryBalances returns the bank balances of address per denom.
+func QueryBalances(cfg *ChainConfig, address string) (map[string]*big.Int, error) {
+	out, err := JunctiondCommand(cfg, "query", "bank", "balances", address, "--output", "json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: error querying balances of %s: %v", ErrChainNotReady, address, err)
+	}
+
+	var response struct {
+		Balances []Coin `json:"balances"`
+	}
+	if err := json.Unmarshal(out, &response); err != nil {
+		return nil, fmt.Errorf("error parsing balances of %s: %v", address, err)
+	}
+
+	balances := map[string]*big.Int{}
+	for _, coin := range response.Balances {
+		amount, ok := new(big.Int).SetString(coin.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s balance %q", coin.Denom, coin.Amount)
+		}
+		balances[coin.Denom] = amount
+	}
+	return balances, nil
+}
+
+// CheckProposerBalance verifies the --from key can pay deposit plus the
+// submit fees, returning an error wrapping ErrInsufficientBalance that names
+// the shortfall per denom if it cannot.
+func CheckProposerBalance(cfg *ChainConfig, deposit string) error {
+	required, err := ParseCoins(deposit + "," + TxFees(cfg, DefaultSubmitFees))
+	if err != nil {
+		return err
+	}
+
+	address, err := KeyAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return fmt.Errorf("error looking up address of key %s: %v", cfg.KeyName, err)
+	}
+	balances, err := QueryBalances(cfg, address)
+	if err != nil {
+		return err
+	}
+
+	var shortfalls []string
+	for denom, amount := range required {
+		balance, ok := balances[denom]
+		if !ok {
+			balance = new(big.Int)
+		}
+		if balance.Cmp(amount) < 0 {
+			missing := new(big.Int).Sub(amount, balance)
+			shortfalls = append(shortfalls, fmt.Sprintf("%s%s (have %s%s, need %s%s)", missing, denom, balance, denom, amount, denom))
+		}
+	}
+	if len(shortfalls) > 0 {
+		sort.Strings(shortfalls)
+		return fmt.Errorf("%w: %s (%s) is short %s for deposit plus fees", ErrInsufficientBalance, cfg.KeyName, address, strings.Join(shortfalls, ", "))
+	}
+	return nil
+}
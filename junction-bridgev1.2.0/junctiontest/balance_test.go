@@ -0,0 +1,46 @@
+package junctiontest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCoins(t *testing.T) {
+	const ibcDenom = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	tests := []struct {
+		name    string
+		coins   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"single coin", "500uamf", map[string]string{"uamf": "500"}, false},
+		{"several coins", "10utest,500uamf", map[string]string{"uamf": "500", "utest": "10"}, false},
+		{"repeated denom is summed", "500uamf, 250uamf", map[string]string{"uamf": "750"}, false},
+		{"ibc denom", "7" + ibcDenom, map[string]string{ibcDenom: "7"}, false},
+		{"beyond int64", "100000000000000000000000uamf", map[string]string{"uamf": "100000000000000000000000"}, false},
+		{"empty", "", map[string]string{}, false},
+		{"empty entries skipped", " ,500uamf,", map[string]string{"uamf": "500"}, false},
+		{"missing amount", "uamf", nil, true},
+		{"missing denom", "500", nil, true},
+		{"decimal amount", "1.5uamf", nil, true},
+		{"space inside coin", "500 uamf", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCoins(tt.coins)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCoins(%q) error = %v, wantErr %v", tt.coins, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			amounts := map[string]string{}
+			for denom, amount := range got {
+				amounts[denom] = amount.String()
+			}
+			if !reflect.DeepEqual(amounts, tt.want) {
+				t.Errorf("ParseCoins(%q) = %v, want %v", tt.coins, amounts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Broadcast modes for ChainConfig.BroadcastMode. BroadcastModeSync returns
+// after CheckTx and BroadcastModeAsync right away, so both leave inclusion to
+// WaitForTx's polling; BroadcastModeBlock returns once the tx is in a block,
+// with its result.
+const (
+	BroadcastModeSync  = "sync"
+	BroadcastModeAsync = "async"
+	BroadcastModeBlock = "block"
+)
+
+var (
+	blockModeMu      sync.Mutex
+	blockModeSupport = map[string]bool{}
+
+	// includedTxsMu guards includedTxs, the results of txs broadcast in
+	// block mode, which WaitForTx returns instead of polling for them.
+	includedTxsMu sync.Mutex
+	includedTxs   = map[string]TxResult{}
+)
+
+// ValidateBroadcastMode checks mode is sync, async or block.
+func ValidateBroadcastMode(mode string) error {
+	switch mode {
+	case BroadcastModeSync, BroadcastModeAsync, BroadcastModeBlock:
+		return nil
+	default:
+		return fmt.Errorf("invalid broadcast_mode %q (expected sync, async or block)", mode)
+	}
+}
+
+// broadcastModeArgs returns the --broadcast-mode flag for the configured
+// BroadcastMode, or none when it is empty. Block mode was removed in Cosmos
+// SDK v0.47, so when the binary does not list it this warns once and falls
+// back to sync.
+func broadcastModeArgs(cfg *ChainConfig) ([]string, error) {
+	if cfg.BroadcastMode == "" {
+		return nil, nil
+	}
+	if err := ValidateBroadcastMode(cfg.BroadcastMode); err != nil {
+		return nil, err
+	}
+	if cfg.BroadcastMode == BroadcastModeBlock && !supportsBlockMode(cfg) {
+		return []string{"--broadcast-mode", BroadcastModeSync}, nil
+	}
+	return []string{"--broadcast-mode", cfg.BroadcastMode}, nil
+}
+
+// supportsBlockMode reads the tx help output for block among the
+// --broadcast-mode values, remembering the answer per binary.
+func supportsBlockMode(cfg *ChainConfig) bool {
+	cacheKey := cfg.Runner + ":" + cfg.JunctiondPath
+	blockModeMu.Lock()
+	defer blockModeMu.Unlock()
+	if supported, ok := blockModeSupport[cacheKey]; ok {
+		return supported
+	}
+
+	help, _ := JunctiondCommand(cfg, "tx", "gov", "vote", "--help").CombinedOutput()
+	supported := strings.Contains(string(help), "|block")
+	if !supported {
+		fmt.Fprintln(os.Stderr, "Warning: this junctiond has no block broadcast mode, using sync and polling for inclusion")
+	}
+	blockModeSupport[cacheKey] = supported
+	return supported
+}
+
+// recordIncludedTx keeps the result of a tx whose broadcast response shows
+// it was already included in a block, so WaitForTx need not poll for it.
+func recordIncludedTx(response []byte) {
+	var result TxResult
+	if err := json.Unmarshal(response, &result); err != nil || result.Height == 0 || result.TxHash == "" {
+		return
+	}
+	includedTxsMu.Lock()
+	defer includedTxsMu.Unlock()
+	includedTxs[result.TxHash] = result
+}
+
+// takeIncludedTx returns and forgets the recorded result for txHash.
+func takeIncludedTx(txHash string) (TxResult, bool) {
+	includedTxsMu.Lock()
+	defer includedTxsMu.Unlock()
+	result, ok := includedTxs[txHash]
+	delete(includedTxs, txHash)
+	return result, ok
+}
@@ -0,0 +1,84 @@
+package junctiontest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateBroadcastMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr bool
+	}{
+		{BroadcastModeSync, false},
+		{BroadcastModeAsync, false},
+		{BroadcastModeBlock, false},
+		{"", true},
+		{"SYNC", true},
+		{"commit", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if err := ValidateBroadcastMode(tt.mode); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBroadcastMode(%q) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBroadcastModeArgs(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{BroadcastModeSync, []string{"--broadcast-mode", "sync"}, false},
+		{BroadcastModeAsync, []string{"--broadcast-mode", "async"}, false},
+		{"commit", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got, err := broadcastModeArgs(&ChainConfig{BroadcastMode: tt.mode})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("broadcastModeArgs(%q) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("broadcastModeArgs(%q) = %q, want %q", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludedTxs(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		recorded bool
+	}{
+		{"block mode result", `{"height":"42","txhash":"ABC","code":0,"gas_used":"1234"}`, true},
+		{"sync mode response", `{"height":"0","txhash":"DEF","code":0}`, false},
+		{"no hash", `{"height":"42","code":0}`, false},
+		{"not json", `gas estimate: 1234`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recordIncludedTx([]byte(tt.response))
+			for _, hash := range []string{"ABC", "DEF", ""} {
+				result, ok := takeIncludedTx(hash)
+				if hash == "ABC" && ok != tt.recorded {
+					t.Fatalf("takeIncludedTx(%q) found = %v, want %v", hash, ok, tt.recorded)
+				}
+				if hash != "ABC" && ok {
+					t.Errorf("takeIncludedTx(%q) found %+v", hash, result)
+				}
+				if ok && (result.Height != 42 || result.GasUsed != 1234) {
+					t.Errorf("takeIncludedTx(%q) = %+v", hash, result)
+				}
+			}
+			if _, ok := takeIncludedTx("ABC"); ok {
+				t.Error("takeIncludedTx did not forget the result")
+			}
+		})
+	}
+}
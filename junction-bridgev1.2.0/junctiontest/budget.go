@@ -0,0 +1,84 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// TestConstraints limits how long individual steps of a run may take, for CI
+// pipelines with strict time budgets.
+type TestConstraints struct {
+	// StepBudget maps a step name (see SetupChain, StartChainBackground and
+	// PassProposal) to the longest it may take. Steps without an entry are
+	// not limited.
+	StepBudget map[string]time.Duration `mapstructure:"step_budget"`
+	// StrictTiming makes a step that overruns its budget fail the run
+	// instead of only logging a warning.
+	StrictTiming bool `mapstructure:"strict_timing"`
+}
+
+// ErrStepOverBudget is returned (or logged) when a step takes longer than its
+// StepBudget entry.
+type ErrStepOverBudget struct {
+	Step   string
+	Budget time.Duration
+	Actual time.Duration
+}
+
+func (e *ErrStepOverBudget) Error() string {
+	return fmt.Sprintf("step %s took %s, over its %s budget", e.Step, e.Actual.Round(time.Millisecond), e.Budget)
+}
+
+// Check returns an *ErrStepOverBudget if step took longer than its budget.
+func (c TestConstraints) Check(step string, actual time.Duration) error {
+	budget, ok := c.StepBudget[step]
+	if !ok || actual <= budget {
+		return nil
+	}
+	return &ErrStepOverBudget{Step: step, Budget: budget, Actual: actual}
+}
+
+// CheckStepBudget compares a finished step's duration to its budget. An
+// overrun is logged as a warning, or returned when StrictTiming is set.
+func (c *ChainConfig) CheckStepBudget(step string, actual time.Duration) error {
+	err := c.Constraints.Check(step, actual)
+	if err == nil || c.Constraints.StrictTiming {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	return nil
+}
+
+// stepTimer times a sequence of named steps, checking each against its
+// budget when the next one starts or the sequence finishes.
+type stepTimer struct {
+	cfg   *ChainConfig
+	step  string
+	start time.Time
+}
+
+func newStepTimer(cfg *ChainConfig) *stepTimer {
+	return &stepTimer{cfg: cfg}
+}
+
+// next finishes the current step and starts timing step.
+func (t *stepTimer) next(step string) error {
+	err := t.finish()
+	t.step = step
+	t.start = time.Now()
+	Metrics.SetPhase(step)
+	return err
+}
+
+// finish checks the current step, if any, against its budget.
+func (t *stepTimer) finish() error {
+	if t.step == "" {
+		return nil
+	}
+	step := t.step
+	t.step = ""
+	actual := time.Since(t.start)
+	Metrics.ObserveStep(step, actual)
+	return t.cfg.CheckStepBudget(step, actual)
+}
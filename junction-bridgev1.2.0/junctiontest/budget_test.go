@@ -0,0 +1,47 @@
+package junctiontest
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConstraintsCheck(t *testing.T) {
+	constraints := TestConstraints{StepBudget: map[string]time.Duration{
+		"init":  10 * time.Second,
+		"start": time.Minute,
+	}}
+	tests := []struct {
+		name       string
+		step       string
+		actual     time.Duration
+		overBudget bool
+	}{
+		{"under budget", "init", 5 * time.Second, false},
+		{"exactly on budget", "start", time.Minute, false},
+		{"over budget", "init", 11 * time.Second, true},
+		{"step without budget", "gentx", time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := constraints.Check(tt.step, tt.actual)
+			if !tt.overBudget {
+				if err != nil {
+					t.Errorf("Check(%q, %s) = %v, want nil", tt.step, tt.actual, err)
+				}
+				return
+			}
+			var overBudget *ErrStepOverBudget
+			if !errors.As(err, &overBudget) {
+				t.Fatalf("Check(%q, %s) = %v, want *ErrStepOverBudget", tt.step, tt.actual, err)
+			}
+			if overBudget.Step != tt.step || overBudget.Actual != tt.actual || overBudget.Budget != constraints.StepBudget[tt.step] {
+				t.Errorf("Check(%q, %s) = %+v", tt.step, tt.actual, overBudget)
+			}
+		})
+	}
+
+	if err := (TestConstraints{}).Check("init", time.Hour); err != nil {
+		t.Errorf("Check without budgets = %v, want nil", err)
+	}
+}
@@ -0,0 +1,55 @@
+package junctiontest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CancelProposal withdraws proposalID with `tx gov cancel-proposal` from the
+// configured key. The chain only lets the proposer cancel, and only while the
+// proposal is in its deposit or voting period, so both are checked first;
+// either failing, a proposer the node does not report, or a junctiond
+// without the command returns an error wrapping ErrProposalNotCancelable.
+func CancelProposal(cfg *ChainConfig, proposalID string) (*TxResponse, error) {
+	info, err := FetchProposal(cfg.RestEndpoint, proposalID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error fetching proposal %s: %v", ErrChainNotReady, proposalID, err)
+	}
+	if info.ID == "" {
+		return nil, fmt.Errorf("proposal %s not found", proposalID)
+	}
+	if info.Status != "PROPOSAL_STATUS_DEPOSIT_PERIOD" && info.Status != "PROPOSAL_STATUS_VOTING_PERIOD" {
+		return nil, fmt.Errorf("%w: proposal %s is already %s; only proposals in the deposit or voting period can be cancelled", ErrProposalNotCancelable, proposalID, info.Status)
+	}
+
+	address, err := KeyAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up %s address: %v", cfg.KeyName, err)
+	}
+	if info.Proposer == "" {
+		return nil, fmt.Errorf("%w: the node does not report proposal %s's proposer, so it cannot be confirmed to be %s (%s)", ErrProposalNotCancelable, proposalID, cfg.KeyName, address)
+	}
+	if info.Proposer != address {
+		return nil, fmt.Errorf("%w: proposal %s was submitted by %s, not %s (%s)", ErrProposalNotCancelable, proposalID, info.Proposer, cfg.KeyName, address)
+	}
+
+	help, err := JunctiondCommand(cfg, "tx", "gov", "cancel-proposal", "--help").CombinedOutput()
+	if err != nil || !strings.Contains(string(help), "cancel-proposal [proposal-id]") {
+		return nil, fmt.Errorf("%w: this junctiond has no `tx gov cancel-proposal` (it needs gov v1 from Cosmos SDK v0.50 or later)", ErrProposalNotCancelable)
+	}
+
+	gasArgs, err := TxGasFlags(cfg, DefaultVoteFees)
+	if err != nil {
+		return nil, err
+	}
+	cancelArgs := append([]string{
+		"tx", "gov", "cancel-proposal", proposalID,
+		"--from", cfg.KeyName,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--output", "json",
+		"-y",
+	}, gasArgs...)
+
+	return RunTxCommand(cfg, JunctiondCommand(cfg, cancelArgs...))
+}
@@ -0,0 +1,525 @@
+package junctiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SetupChain runs the node initialization steps (cleanup, init, keys,
+// genesis accounts, gentx, genesis, app.toml and config.toml changes) without
+// starting the node.
+func SetupChain(cfg *ChainConfig) error {
+	if err := CheckJunctiond(cfg); err != nil {
+		return err
+	}
+	if err := PinBinaryVersion(cfg); err != nil {
+		return err
+	}
+
+	timer := newStepTimer(cfg)
+
+	// Step 1: Remove existing junctiond directory. A leftover directory
+	// would make init fail or mix old state into the new chain, so a failed
+	// removal stops the setup. With SkipCleanup nothing is ever removed
+	// (see planHomeSetup).
+	if err := cfg.CheckHome(); err != nil {
+		return err
+	}
+	cleanup, overwrite, err := planHomeSetup(cfg)
+	if err != nil {
+		return err
+	}
+	extraAccounts, err := ParseExtraAccounts(cfg.ExtraAccounts)
+	if err != nil {
+		return err
+	}
+	mintPatches, err := MintGenesisPatches(cfg)
+	if err != nil {
+		return err
+	}
+	homeDir := cfg.Home()
+	if err := timer.next("cleanup"); err != nil {
+		return err
+	}
+	if cleanup {
+		fmt.Printf("\n📁 Removing existing junctiond directory %s...\n", homeDir)
+		if err := os.RemoveAll(homeDir); err != nil {
+			return fmt.Errorf("could not remove %s: %v; remove it manually (check permissions and that no junctiond is still running) and retry", homeDir, err)
+		}
+	} else if overwrite {
+		fmt.Fprintf(os.Stderr, "Warning: skip_cleanup_force is set, keeping %s but replacing its genesis\n", homeDir)
+	} else if cfg.SkipCleanupForce {
+		fmt.Fprintf(os.Stderr, "Warning: skip_cleanup_force is set, setting up on top of whatever is in %s\n", homeDir)
+	} else {
+		fmt.Printf("\n📁 Keeping junctiond directory %s (skip_cleanup)\n", homeDir)
+	}
+
+	// Step 2: Initialize the junctiond node
+	if err := timer.next("init"); err != nil {
+		return err
+	}
+	fmt.Println("\n🔧 Initializing junctiond node...")
+	initArgs := []string{"init", cfg.Moniker, "--default-denom", cfg.Denom, "--chain-id", cfg.ChainID}
+	if overwrite {
+		initArgs = append(initArgs, "--overwrite")
+	}
+	initCmd := JunctiondCommand(cfg, initArgs...)
+	if err := RunCommand(initCmd); err != nil {
+		return fmt.Errorf("error initializing node: %v", err)
+	}
+
+	// Steps 3-6: Build genesis with our validator, or install the
+	// configured GenesisFile in its place
+	if cfg.GenesisFile != "" {
+		if err := timer.next("genesis_file"); err != nil {
+			return err
+		}
+		if len(extraAccounts) > 0 {
+			fmt.Fprintln(os.Stderr, "Warning: extra_accounts are ignored when genesis_file is set")
+		}
+		fmt.Printf("\n📄 Installing genesis from %s...\n", cfg.GenesisFile)
+		if err := InstallGenesisFile(cfg, homeDir); err != nil {
+			return err
+		}
+	} else if err := generateGenesis(cfg, homeDir, extraAccounts, timer); err != nil {
+		return err
+	}
+
+	// Step 7: Validate genesis (mandatory, never skipped)
+	if err := timer.next("validate_genesis"); err != nil {
+		return err
+	}
+	fmt.Println("\n🛡️ Validating genesis and gentxs...")
+	if err := ValidateGentx(cfg); err != nil {
+		return err
+	}
+
+	// Step 8: Modify genesis file
+	if err := timer.next("genesis"); err != nil {
+		return err
+	}
+	if cfg.SkipGenesisPatches {
+		fmt.Println("\n⏭️ Keeping genesis gov periods as they are (skip_genesis_patches)")
+	} else {
+		fmt.Println("\n⚙️ Modifying genesis file...")
+		before, err := ReadGovParams(homeDir)
+		if err != nil {
+			return err
+		}
+		if err := ModifyGenesisFile(homeDir); err != nil {
+			return fmt.Errorf("error modifying genesis file: %v", err)
+		}
+		if cfg.Verbose {
+			after, err := ReadGovParams(homeDir)
+			if err != nil {
+				return err
+			}
+			PrintParamDiff("gov params", before, after)
+		}
+	}
+	if len(mintPatches) > 0 {
+		fmt.Println("\n⚙️ Setting genesis mint params...")
+		if err := ApplyGenesisPatches(homeDir, mintPatches); err != nil {
+			return fmt.Errorf("error setting genesis mint params: %v", err)
+		}
+	}
+
+	// Step 9: Modify app.toml file
+	if err := timer.next("node_config"); err != nil {
+		return err
+	}
+	fmt.Println("\n🔧 Modifying app.toml file...")
+	if err := ModifyAppTomlFile(homeDir); err != nil {
+		return fmt.Errorf("error modifying app.toml file: %v", err)
+	}
+
+	// Step 10: Allow browser frontends to reach the API and RPC
+	if len(cfg.CORSOrigins) > 0 {
+		fmt.Printf("\n🌐 Enabling CORS for %s...\n", strings.Join(cfg.CORSOrigins, ", "))
+		if err := SetAPICORSAllowedOrigins(homeDir, cfg.CORSOrigins); err != nil {
+			return fmt.Errorf("error enabling CORS: %v", err)
+		}
+	}
+
+	// Step 11: Point the node at its DNS seeds
+	if len(cfg.DNSSeeds) > 0 {
+		fmt.Printf("\n🌍 Setting p2p seeds to %s...\n", strings.Join(cfg.DNSSeeds, ", "))
+		if err := SetDNSSeeds(homeDir, cfg.DNSSeeds); err != nil {
+			return fmt.Errorf("error setting DNS seeds: %v", err)
+		}
+	}
+
+	// Step 12: Apply user overrides to app.toml and config.toml
+	for _, file := range []struct {
+		name string
+		spec string
+		edit func(string, map[string]string) error
+	}{
+		{"app.toml", cfg.AppTomlOverrides, EditAppToml},
+		{"config.toml", cfg.ConfigTomlOverrides, EditConfigToml},
+	} {
+		if file.spec == "" {
+			continue
+		}
+		overrides, err := ParseTomlOverrides(file.spec)
+		if err != nil {
+			return fmt.Errorf("error parsing %s overrides: %v", file.name, err)
+		}
+		fmt.Printf("\n🔧 Applying %s overrides...\n", file.name)
+		if err := file.edit(homeDir, overrides); err != nil {
+			return err
+		}
+	}
+
+	return timer.finish()
+}
+
+// generateGenesis creates the validator key, funds it and any extra
+// accounts in genesis, and collects the validator's gentx.
+func generateGenesis(cfg *ChainConfig, homeDir string, extraAccounts []ExtraAccount, timer *stepTimer) error {
+	// Step 3: Generate keys (or use existing)
+	if err := timer.next("keys"); err != nil {
+		return err
+	}
+	fmt.Println("\n🔑 Generating keys...")
+	if err := EnsureKey(cfg, cfg.KeyName); err != nil {
+		return err
+	}
+
+	// Step 4: Add genesis accounts (or use existing): the validator's, then
+	// any ExtraAccounts
+	if err := timer.next("genesis_account"); err != nil {
+		return err
+	}
+	fmt.Println("\n💰 Adding genesis account...")
+	if err := addGenesisAccount(cfg, homeDir, cfg.KeyName, cfg.Amount); err != nil {
+		return err
+	}
+	if err := addExtraAccounts(cfg, homeDir, extraAccounts); err != nil {
+		return err
+	}
+
+	// Step 5: Stake validator account
+	if err := timer.next("gentx"); err != nil {
+		return err
+	}
+	fmt.Println("\n🏛️ Staking validator account...")
+	gentxCmd := JunctiondCommand(cfg, "genesis", "gentx", cfg.KeyName, cfg.ValidatorStake, "--keyring-backend", "os", "--gas-prices", "0.0025uamf", "--chain-id", cfg.ChainID)
+	if err := RunCommand(gentxCmd); err != nil {
+		return fmt.Errorf("error creating gentx: %v", err)
+	}
+
+	// Step 6: Collect gentx files
+	if err := timer.next("collect_gentxs"); err != nil {
+		return err
+	}
+	fmt.Println("\n📋 Collecting gentx files...")
+	collectGentxCmd := JunctiondCommand(cfg, "genesis", "collect-gentxs")
+	if err := RunCommand(collectGentxCmd); err != nil {
+		return fmt.Errorf("error collecting gentx files: %v", err)
+	}
+	return nil
+}
+
+// ValidateGentx runs `junctiond genesis validate-genesis` on the collected
+// genesis so a node is never started from an invalid genesis or gentx. Like
+// init, gentx, collect-gentxs and start, it uses junctiond's default home,
+// so it checks the genesis the node will start from. It takes the
+// ChainConfig rather than a home directory because JunctiondCommand needs
+// the runner settings.
+func ValidateGentx(cfg *ChainConfig) error {
+	validateCmd := JunctiondCommand(cfg, "genesis", "validate-genesis")
+	if err := RunCommand(validateCmd); err != nil {
+		return fmt.Errorf("genesis validation failed, refusing to start: %v", err)
+	}
+	return nil
+}
+
+// RunChain starts the node in the foreground, restarting it up to
+// cfg.MaxRestarts times if it exits while cfg.RestartOnCrash is set. Output
+// is also appended to ChainLogPath so other tools can follow it.
+func RunChain(cfg *ChainConfig) error {
+	args, err := StartArgs(cfg)
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(ChainLogPath(cfg), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error creating log file: %v", err)
+	}
+	defer logFile.Close()
+
+	for restarts := 0; ; restarts++ {
+		startCmd := JunctiondCommand(cfg, args...)
+		startCmd.Stdout = io.MultiWriter(os.Stdout, logFile)
+		startCmd.Stderr = io.MultiWriter(os.Stderr, logFile)
+
+		err := startCmd.Start()
+		if err == nil {
+			writePIDFile(cfg, startCmd.Process.Pid)
+			err = startCmd.Wait()
+
+			// StopChainFromPIDFile removes the PID file before signaling
+			if _, statErr := os.Stat(PIDFilePath(cfg)); os.IsNotExist(statErr) {
+				fmt.Println("🛑 junctiond was stopped by the proposal flow")
+				return nil
+			}
+			os.Remove(PIDFilePath(cfg))
+		}
+		if !cfg.RestartOnCrash || restarts >= cfg.MaxRestarts {
+			if err != nil {
+				return fmt.Errorf("error starting node: %v", err)
+			}
+			return nil
+		}
+
+		// Watchdog: bring a crashed node back up
+		fmt.Fprintf(os.Stderr, "Warning: junctiond exited (%v), restarting (%d/%d)...\n", err, restarts+1, cfg.MaxRestarts)
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// StartChainBackground starts the node without blocking, logging to
+// junctiond.log in the home directory, and waits for the first block. The
+// process is watched so crashes during later waits are detected.
+func StartChainBackground(cfg *ChainConfig) error {
+	if err := startChainProcess(cfg); err != nil {
+		return err
+	}
+	watchdog = startWatchdog(cfg)
+
+	fmt.Println("⏳ Waiting for the chain to produce blocks...")
+	start := time.Now()
+	if err := waitForHeightBackoff(context.Background(), cfg.RPCEndpoint, 1, 60*time.Second, cfg.ReadyBackoff()); err != nil {
+		return fmt.Errorf("%w: chain did not start: %v", ErrChainNotReady, err)
+	}
+	return cfg.CheckStepBudget("start", time.Since(start))
+}
+
+// planHomeSetup decides what SetupChain does with home_dir before init.
+// Without SkipCleanup it is removed. With SkipCleanup it is kept, and it
+// must be missing or empty unless SkipCleanupForce is also set. In that case
+// init runs with --overwrite if a genesis is already there, since init
+// refuses to replace one otherwise; keys and node keys in the home are kept.
+func planHomeSetup(cfg *ChainConfig) (cleanup, overwrite bool, err error) {
+	if !cfg.SkipCleanup {
+		return true, false, nil
+	}
+	if !cfg.SkipCleanupForce {
+		return false, false, checkHomeEmpty(cfg.Home())
+	}
+	_, err = os.Stat(filepath.Join(cfg.Home(), "config", "genesis.json"))
+	return false, err == nil, nil
+}
+
+// checkHomeEmpty returns an error if homeDir exists and is not empty, so
+// SkipCleanup never sets up a chain on top of an existing node.
+func checkHomeEmpty(homeDir string) error {
+	entries, err := os.ReadDir(homeDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", homeDir, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("skip_cleanup is set but %s is not empty; it may hold a node set up separately, so remove it yourself or point home_dir elsewhere", homeDir)
+	}
+	return nil
+}
+
+// ChainLogPath is where StartChainBackground writes the node's output.
+func ChainLogPath(cfg *ChainConfig) string {
+	return filepath.Join(cfg.Home(), "junctiond.log")
+}
+
+// startChainProcess launches junctiond start and registers it as
+// "junctiond" in the process registry.
+func startChainProcess(cfg *ChainConfig) error {
+	args, err := StartArgs(cfg)
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(ChainLogPath(cfg), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error creating log file: %v", err)
+	}
+
+	// With FollowLogs the output is also echoed to stderr, keeping stdout
+	// clean for --print-proposal-id. Sharing one writer makes exec merge
+	// stdout and stderr into a single pipe, so lines never interleave.
+	var output io.Writer = logFile
+	if cfg.FollowLogs {
+		output = io.MultiWriter(logFile, newLineWriter(os.Stderr, "│ junctiond: "))
+	}
+	startCmd := JunctiondCommand(cfg, args...)
+	startCmd.Stdout = output
+	startCmd.Stderr = output
+	if err := startCmd.Start(); err != nil {
+		logFile.Close()
+		return fmt.Errorf("error starting node: %v", err)
+	}
+	Processes.Register("junctiond", startCmd)
+	writePIDFile(cfg, startCmd.Process.Pid)
+	return nil
+}
+
+// KeyAddress returns the bech32 address of a key in the os keyring.
+func KeyAddress(cfg *ChainConfig, keyName string) (string, error) {
+	out, err := JunctiondCommand(cfg, "keys", "show", keyName, "-a", "--keyring-backend", "os").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GenesisHasAccount reports whether address already has an auth account or a
+// bank balance in the node's genesis file.
+func GenesisHasAccount(homeDir, address string) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(homeDir, "config", "genesis.json"))
+	if err != nil {
+		return false, fmt.Errorf("error reading genesis file: %v", err)
+	}
+
+	var genesis struct {
+		AppState struct {
+			Auth struct {
+				Accounts []struct {
+					Address string `json:"address"`
+				} `json:"accounts"`
+			} `json:"auth"`
+			Bank struct {
+				Balances []struct {
+					Address string `json:"address"`
+				} `json:"balances"`
+			} `json:"bank"`
+		} `json:"app_state"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return false, fmt.Errorf("error parsing genesis file: %v", err)
+	}
+
+	for _, account := range genesis.AppState.Auth.Accounts {
+		if account.Address == address {
+			return true, nil
+		}
+	}
+	for _, balance := range genesis.AppState.Bank.Balances {
+		if balance.Address == address {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EnsureKey creates keyName in the os keyring unless it already exists. The
+// proposer key (cfg.KeyName) is recovered from cfg.KeyMnemonic when set, so
+// its address is the same on every run.
+func EnsureKey(cfg *ChainConfig, keyName string) error {
+	if keyName == cfg.KeyName && cfg.KeyMnemonic != "" {
+		return recoverKey(cfg, keyName, cfg.KeyMnemonic)
+	}
+
+	checkKeyCmd := JunctiondCommand(cfg, "keys", "show", keyName, "--keyring-backend", "os")
+	if err := checkKeyCmd.Run(); err == nil {
+		fmt.Printf("✅ Using existing key: %s\n", keyName)
+		return nil
+	}
+
+	fmt.Printf("🔑 Creating new key: %s\n", keyName)
+	keyCmd := JunctiondCommand(cfg, "keys", "add", keyName, "--keyring-backend", "os")
+	if err := RunCommand(keyCmd); err != nil {
+		return fmt.Errorf("error generating key %s: %v", keyName, err)
+	}
+	return nil
+}
+
+// recoverKey imports keyName from mnemonic with `keys add --recover`. If the
+// key already exists it must have the mnemonic's address; a different key
+// under the same name is an error rather than being replaced.
+func recoverKey(cfg *ChainConfig, keyName, mnemonic string) error {
+	expected, err := mnemonicAddress(cfg, keyName, mnemonic)
+	if err != nil {
+		return err
+	}
+
+	if existing, err := KeyAddress(cfg, keyName); err == nil {
+		if existing != expected {
+			return fmt.Errorf("key %s already exists with address %s, but key_mnemonic gives %s; delete it with `junctiond keys delete %s --keyring-backend os` to import the mnemonic", keyName, existing, expected, keyName)
+		}
+		fmt.Printf("✅ Using existing key: %s (%s)\n", keyName, existing)
+		return nil
+	}
+
+	fmt.Printf("🔑 Recovering key %s from key_mnemonic\n", keyName)
+	recoverCmd := JunctiondCommand(cfg, "keys", "add", keyName, "--recover", "--keyring-backend", "os")
+	recoverCmd.Stdin = strings.NewReader(strings.TrimSpace(mnemonic) + "\n")
+	if out, err := recoverCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error recovering key %s: %v: %s", keyName, err, out)
+	}
+	fmt.Printf("✅ Recovered key %s: %s\n", keyName, expected)
+	return nil
+}
+
+// mnemonicAddress returns the address mnemonic derives, without storing the
+// key.
+func mnemonicAddress(cfg *ChainConfig, keyName, mnemonic string) (string, error) {
+	dryRunCmd := JunctiondCommand(cfg, "keys", "add", keyName, "--recover", "--dry-run", "--keyring-backend", "os", "--output", "json")
+	dryRunCmd.Stdin = strings.NewReader(strings.TrimSpace(mnemonic) + "\n")
+	out, err := dryRunCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error deriving address from key_mnemonic (is it a valid bip39 mnemonic?): %v", err)
+	}
+
+	var key struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(out, &key); err != nil {
+		return "", fmt.Errorf("error parsing derived key: %v", err)
+	}
+	return key.Address, nil
+}
+
+// RunCommand runs cmd with its output attached to the terminal.
+func RunCommand(cmd *exec.Cmd) error {
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// CheckBinary verifies that the given binary can be found, either as a path
+// or on PATH.
+func CheckBinary(path string) error {
+	if _, err := exec.LookPath(path); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrMissingDependency, path, err)
+	}
+	return nil
+}
+
+// DetectJunctiondVersion returns the output of `junctiond version`, or
+// "unavailable" if the binary cannot be run.
+func DetectJunctiondVersion(cfg *ChainConfig) string {
+	out, err := JunctiondCommand(cfg, "version").CombinedOutput()
+	if err != nil {
+		return "unavailable"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// DetectJunctiondVersionLong returns the output of `junctiond version
+// --long` (version, commit, build tags, Go and dependency versions), or
+// "unavailable" if it cannot be run.
+func DetectJunctiondVersionLong(cfg *ChainConfig) string {
+	out, err := JunctiondCommand(cfg, "version", "--long").CombinedOutput()
+	if err != nil {
+		return "unavailable"
+	}
+	return strings.TrimSpace(string(out))
+}
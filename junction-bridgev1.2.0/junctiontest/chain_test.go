@@ -0,0 +1,67 @@
+package junctiontest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlanHomeSetup(t *testing.T) {
+	tests := []struct {
+		name          string
+		skipCleanup   bool
+		force         bool
+		home          string // "missing", "empty", "files" or "genesis"
+		wantCleanup   bool
+		wantOverwrite bool
+		wantErr       bool
+	}{
+		{"cleanup with node", false, false, "genesis", true, false, false},
+		{"cleanup ignores force", false, true, "genesis", true, false, false},
+		{"skip with missing home", true, false, "missing", false, false, false},
+		{"skip with empty home", true, false, "empty", false, false, false},
+		{"skip with files", true, false, "files", false, false, true},
+		{"skip with node", true, false, "genesis", false, false, true},
+		{"force with missing home", true, true, "missing", false, false, false},
+		{"force with files", true, true, "files", false, false, false},
+		{"force with node overwrites genesis", true, true, "genesis", false, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := filepath.Join(t.TempDir(), "home")
+			switch tt.home {
+			case "empty":
+				mustMkdir(t, home)
+			case "files":
+				mustMkdir(t, home)
+				mustWrite(t, filepath.Join(home, "notes.txt"))
+			case "genesis":
+				mustMkdir(t, filepath.Join(home, "config"))
+				mustWrite(t, filepath.Join(home, "config", "genesis.json"))
+			}
+
+			cfg := &ChainConfig{HomeDir: home, SkipCleanup: tt.skipCleanup, SkipCleanupForce: tt.force}
+			cleanup, overwrite, err := planHomeSetup(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planHomeSetup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cleanup != tt.wantCleanup || overwrite != tt.wantOverwrite {
+				t.Errorf("planHomeSetup() = cleanup %v, overwrite %v, want %v, %v", cleanup, overwrite, tt.wantCleanup, tt.wantOverwrite)
+			}
+		})
+	}
+}
+
+func mustMkdir(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustWrite(t *testing.T, path string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
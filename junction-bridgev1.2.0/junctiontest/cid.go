@@ -0,0 +1,94 @@
+package junctiontest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// ErrCIDNotVerifiable means a CID cannot be recomputed locally: it is not a
+// CIDv0 (Qm...) or the file is larger than one IPFS chunk.
+var ErrCIDNotVerifiable = errors.New("CID cannot be verified locally")
+
+// ipfsChunkSize is the default chunk size of `ipfs add`. Files up to this size
+// are stored as a single block, so their CID depends on the content alone.
+const ipfsChunkSize = 256 * 1024
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// VerifyLocalCID checks cid is the CIDv0 `ipfs add` gives the file at
+// filePath with default settings, catching copy-paste errors without an IPFS
+// network call. A CIDv0 is the base58btc SHA-256 multihash of the file
+// wrapped in a UnixFS dag-pb node. CIDv1s and files over 256 KiB return an
+// error wrapping ErrCIDNotVerifiable.
+func VerifyLocalCID(filePath, cid string) error {
+	if !strings.HasPrefix(cid, "Qm") || len(cid) != 46 {
+		return fmt.Errorf("%w: %s is not a CIDv0", ErrCIDNotVerifiable, cid)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", filePath, err)
+	}
+	if len(data) > ipfsChunkSize {
+		return fmt.Errorf("%w: %s is larger than one %d byte chunk", ErrCIDNotVerifiable, filePath, ipfsChunkSize)
+	}
+
+	expected := ComputeCIDv0(data)
+	if expected != cid {
+		return fmt.Errorf("CID %s does not match %s (expected %s); check the CID was copied correctly and the file was not edited after upload", cid, filePath, expected)
+	}
+	return nil
+}
+
+// ComputeCIDv0 returns the CIDv0 of data stored as a single UnixFS file
+// block.
+func ComputeCIDv0(data []byte) string {
+	// UnixFS Data message: Type=File, Data, filesize
+	unixfs := protoVarintField(nil, 1, 2)
+	if len(data) > 0 {
+		unixfs = protoBytesField(unixfs, 2, data)
+	}
+	unixfs = protoVarintField(unixfs, 3, uint64(len(data)))
+
+	// dag-pb PBNode with only Data set
+	node := protoBytesField(nil, 1, unixfs)
+
+	digest := sha256.Sum256(node)
+	return base58Encode(append([]byte{0x12, 0x20}, digest[:]...))
+}
+
+func protoVarintField(buf []byte, field int, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field<<3))
+	return binary.AppendUvarint(buf, value)
+}
+
+func protoBytesField(buf []byte, field int, value []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field<<3|2))
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func base58Encode(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
@@ -0,0 +1,77 @@
+package junctiontest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CIDCacheEntry is the CID a metadata file was uploaded under.
+type CIDCacheEntry struct {
+	CID      string    `json:"cid"`
+	CachedAt time.Time `json:"cached_at"`
+}
+
+// CIDCache maps the hex SHA-256 of a metadata file's content to its CID, so
+// unchanged metadata does not need to be uploaded and entered again.
+type CIDCache map[string]CIDCacheEntry
+
+// LoadCIDCache reads the cache at path. A missing file is an empty cache.
+func LoadCIDCache(path string) (CIDCache, error) {
+	cache := CIDCache{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	return cache, nil
+}
+
+// Save writes the cache to path.
+func (c CIDCache) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling CID cache: %v", err)
+	}
+	if err := WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
+
+// Lookup returns the CID cached for the current content of filePath, if any.
+func (c CIDCache) Lookup(filePath string) (string, bool, error) {
+	key, err := fileSHA256(filePath)
+	if err != nil {
+		return "", false, err
+	}
+	entry, ok := c[key]
+	return entry.CID, ok && entry.CID != "", nil
+}
+
+// Store records cid for the current content of filePath.
+func (c CIDCache) Store(filePath, cid string) error {
+	key, err := fileSHA256(filePath)
+	if err != nil {
+		return err
+	}
+	c[key] = CIDCacheEntry{CID: cid, CachedAt: time.Now().UTC()}
+	return nil
+}
+
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %v", path, err)
+	}
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:]), nil
+}
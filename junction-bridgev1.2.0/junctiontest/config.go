@@ -0,0 +1,240 @@
+// Package junctiontest contains the Junction chain setup, governance and
+// scenario logic used by the junction-bridge CLI, so it can also be driven
+// from Go test suites.
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ChainConfig describes the chain under test and how to talk to it.
+type ChainConfig struct {
+	Moniker                    string   `mapstructure:"moniker"`
+	ChainID                    string   `mapstructure:"chain_id"`
+	Denom                      string   `mapstructure:"denom"`
+	KeyName                    string   `mapstructure:"key_name"`
+	KeyMnemonic                string   `mapstructure:"key_mnemonic"`
+	Amount                     string   `mapstructure:"amount"`
+	ValidatorStake             string   `mapstructure:"validator_stake"`
+	ExtraAccounts              []string `mapstructure:"extra_accounts"`
+	KeyWorkers                 int      `mapstructure:"key_workers"`
+	GenesisFile                string   `mapstructure:"genesis_file"`
+	SkipGenesisPatches         bool     `mapstructure:"skip_genesis_patches"`
+	GenesisInflation           string   `mapstructure:"genesis_inflation"`
+	GenesisInflationMin        string   `mapstructure:"genesis_inflation_min"`
+	GenesisInflationMax        string   `mapstructure:"genesis_inflation_max"`
+	GenesisInflationRateChange string   `mapstructure:"genesis_inflation_rate_change"`
+	GenesisGoalBonded          string   `mapstructure:"genesis_goal_bonded"`
+	JunctiondPath              string   `mapstructure:"junctiond_path"`
+	JunctiondSHA256            string   `mapstructure:"junctiond_sha256"`
+	Runner                     string   `mapstructure:"runner"`
+	ProposalFormat             string   `mapstructure:"proposal_format"`
+	GovVersion                 string   `mapstructure:"gov_version"`
+	DockerImage                string   `mapstructure:"docker_image"`
+	HomeDir                    string   `mapstructure:"home_dir"`
+	SkipCleanup                bool     `mapstructure:"skip_cleanup"`
+	SkipCleanupForce           bool     `mapstructure:"skip_cleanup_force"`
+	SnapshotDir                string   `mapstructure:"snapshot_dir"`
+	Workdir                    string   `mapstructure:"workdir"`
+	OutputDir                  string   `mapstructure:"output_dir"`
+	OutputFileMode             string   `mapstructure:"output_file_mode"`
+	MinimumGasPrices           string   `mapstructure:"minimum_gas_prices"`
+	AppTomlOverrides           string   `mapstructure:"app_toml_overrides"`
+	ConfigTomlOverrides        string   `mapstructure:"config_toml_overrides"`
+	CORSOrigins                []string `mapstructure:"cors_origins"`
+	DNSSeeds                   []string `mapstructure:"dns_seeds"`
+	RestEndpoint               string   `mapstructure:"rest_endpoint"`
+	RPCEndpoint                string   `mapstructure:"rpc_endpoint"`
+	GRPCEndpoint               string   `mapstructure:"grpc_endpoint"`
+	ExplorerURL                string   `mapstructure:"explorer_url"`
+	HTTPAddr                   string   `mapstructure:"http_addr"`
+	MetricsAddr                string   `mapstructure:"metrics_addr"`
+	WebhookURL                 string   `mapstructure:"webhook_url"`
+	IgnoreVersionPin           bool     `mapstructure:"ignore_version_pin"`
+	Verbose                    bool     `mapstructure:"verbose"`
+	TUI                        bool     `mapstructure:"tui"`
+	SingleProcess              bool     `mapstructure:"single_process"`
+	FollowLogs                 bool     `mapstructure:"follow_logs"`
+	StartFlags                 []string `mapstructure:"start_flags"`
+	Pruning                    string   `mapstructure:"pruning"`
+	SkipInvariants             bool     `mapstructure:"skip_invariants"`
+	LogLevel                   string   `mapstructure:"log_level"`
+	Mode                       string   `mapstructure:"mode"`
+	TestChainIDs               []string `mapstructure:"test_chain_ids"`
+	AllowNonTestChain          bool     `mapstructure:"allow_non_test_chain"`
+	StrictConfig               bool     `mapstructure:"strict_config"`
+	SimulateFirst              bool     `mapstructure:"simulate_first"`
+	SignProposal               bool     `mapstructure:"sign_proposal"`
+
+	GasMode       string  `mapstructure:"gas_mode"`
+	GasAdjustment float64 `mapstructure:"gas_adjustment"`
+	GasLimit      uint64  `mapstructure:"gas_limit"`
+	Fees          string  `mapstructure:"fees"`
+	BroadcastMode string  `mapstructure:"broadcast_mode"`
+
+	Expedited            bool     `mapstructure:"expedited"`
+	ProposalMessagesFile string   `mapstructure:"proposal_messages_file"`
+	ProposalAuthors      string   `mapstructure:"proposal_authors"`
+	MetadataTemplate     string   `mapstructure:"metadata_template"`
+	VoteOptionContext    string   `mapstructure:"vote_option_context"`
+	ExpectStatus         string   `mapstructure:"expect_status"`
+	ExpectTallyYesMin    float64  `mapstructure:"expect_tally_yes_min"`
+	ExpectBridgeWorkers  []string `mapstructure:"expect_bridge_workers"`
+	ExpectBridgeContract string   `mapstructure:"expect_bridge_contract"`
+	PostHook             string   `mapstructure:"post_hook"`
+	IPFSGateway          string   `mapstructure:"ipfs_gateway"`
+	IPFSGateways         []string `mapstructure:"ipfs_gateways"`
+	VerifyIPFS           bool     `mapstructure:"verify_ipfs"`
+	IPFSCID              string   `mapstructure:"ipfs_cid"`
+	CIDCacheFile         string   `mapstructure:"cid_cache_file"`
+
+	SyncTimeout      time.Duration `mapstructure:"sync_timeout"`
+	ReadyPollInitial time.Duration `mapstructure:"ready_poll_initial"`
+	ReadyPollMax     time.Duration `mapstructure:"ready_poll_max"`
+	IPFSTimeout      time.Duration `mapstructure:"ipfs_timeout"`
+	Confirmations    int           `mapstructure:"confirmations"`
+	WaitMode         string        `mapstructure:"wait_mode"`
+	Spinner          string        `mapstructure:"spinner"`
+	SpinnerInterval  time.Duration `mapstructure:"spinner_interval"`
+	ProposalTimeout  int           `mapstructure:"proposal_timeout"`
+	WebhookTimeout   time.Duration `mapstructure:"webhook_timeout"`
+	WebhookRetries   int           `mapstructure:"webhook_retries"`
+	RestartOnCrash   bool          `mapstructure:"restart_on_crash"`
+	KeepRunning      bool          `mapstructure:"keep_running"`
+	MaxRestarts      int           `mapstructure:"max_restarts"`
+
+	MaxMemoryGrowthKBPerBlock float64       `mapstructure:"max_memory_growth_kb_per_block"`
+	EnduranceDuration         time.Duration `mapstructure:"endurance_duration"`
+
+	RelayerPath         string `mapstructure:"relayer_path"`
+	RelayerHome         string `mapstructure:"relayer_home"`
+	RelayerMnemonicFile string `mapstructure:"relayer_mnemonic_file"`
+
+	Constraints TestConstraints `mapstructure:",squash"`
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden.
+func DefaultConfig() ChainConfig {
+	return ChainConfig{
+		Moniker:                   "junction-testing",
+		ChainID:                   "junction",
+		Denom:                     "uamf",
+		KeyName:                   "test1",
+		Amount:                    "100000000000uamf",
+		ValidatorStake:            "10000000000uamf",
+		JunctiondPath:             "./build/junctiond",
+		Runner:                    RunnerLocal,
+		Mode:                      ModeLocal,
+		KeyWorkers:                4,
+		TestChainIDs:              DefaultTestChainIDs,
+		GovVersion:                GovVersionAuto,
+		ProposalFormat:            ProposalFormatV1,
+		HomeDir:                   "$HOME/.junction",
+		SnapshotDir:               "$HOME/.junction-snapshots",
+		OutputDir:                 ".",
+		OutputFileMode:            "0644",
+		MinimumGasPrices:          "0.00025uamf",
+		RestEndpoint:              "http://localhost:1317",
+		RPCEndpoint:               "http://localhost:26657",
+		GRPCEndpoint:              "http://localhost:9090",
+		GasMode:                   "auto",
+		GasAdjustment:             1.5,
+		GasLimit:                  200000,
+		BroadcastMode:             BroadcastModeSync,
+		VoteOptionContext:         "yes,no,abstain",
+		IPFSGateway:               "https://ipfs.io/ipfs/",
+		CIDCacheFile:              "ipfs_cid_cache.json",
+		SyncTimeout:               2 * time.Minute,
+		ReadyPollInitial:          500 * time.Millisecond,
+		ReadyPollMax:              10 * time.Second,
+		IPFSTimeout:               10 * time.Second,
+		Confirmations:             1,
+		WaitMode:                  WaitModeTime,
+		Spinner:                   "dots",
+		SpinnerInterval:           100 * time.Millisecond,
+		WebhookTimeout:            10 * time.Second,
+		WebhookRetries:            3,
+		MaxRestarts:               3,
+		KeepRunning:               true,
+		MaxMemoryGrowthKBPerBlock: 100,
+		EnduranceDuration:         30 * time.Minute,
+		RelayerPath:               "hermes",
+		RelayerHome:               "$HOME/.junction-relayer",
+		RelayerMnemonicFile:       "./relayer_mnemonic.txt",
+	}
+}
+
+// ProposalAuthorList splits the comma-separated ProposalAuthors, falling back
+// to the proposer key name when none are configured.
+func (c *ChainConfig) ProposalAuthorList() []string {
+	var authors []string
+	for _, author := range strings.Split(c.ProposalAuthors, ",") {
+		if author = strings.TrimSpace(author); author != "" {
+			authors = append(authors, author)
+		}
+	}
+	if len(authors) == 0 {
+		return []string{c.KeyName}
+	}
+	return authors
+}
+
+// IPFSGatewayList returns the gateways to try in order: IPFSGateways when
+// set, otherwise just IPFSGateway.
+func (c *ChainConfig) IPFSGatewayList() []string {
+	var gateways []string
+	for _, gateway := range c.IPFSGateways {
+		if gateway = strings.TrimSpace(gateway); gateway != "" {
+			gateways = append(gateways, gateway)
+		}
+	}
+	if len(gateways) == 0 && c.IPFSGateway != "" {
+		return []string{c.IPFSGateway}
+	}
+	return gateways
+}
+
+// VotingPeriod returns how long proposals submitted with this config stay in
+// the voting period.
+func (c *ChainConfig) VotingPeriod() time.Duration {
+	if c.Expedited {
+		return ExpeditedVotingPeriod
+	}
+	return VotingPeriod
+}
+
+// Home returns HomeDir with environment variables expanded.
+func (c *ChainConfig) Home() string {
+	return os.ExpandEnv(c.HomeDir)
+}
+
+// CheckHome returns an error if HomeDir refers to an unset or empty
+// environment variable (an empty HOME turns "$HOME/.junction" into
+// "/.junction") or resolves to a directory that must never be wiped, such as
+// the filesystem root or the user's home itself.
+func (c *ChainConfig) CheckHome() error {
+	var unset []string
+	os.Expand(c.HomeDir, func(name string) string {
+		if os.Getenv(name) == "" {
+			unset = append(unset, "$"+name)
+		}
+		return ""
+	})
+	if len(unset) > 0 {
+		return fmt.Errorf("home_dir %q uses %s, which is not set; set it or give home_dir as an absolute path", c.HomeDir, strings.Join(unset, ", "))
+	}
+
+	home, err := filepath.Abs(c.Home())
+	if err != nil {
+		return fmt.Errorf("error resolving home_dir %q: %v", c.HomeDir, err)
+	}
+	userHome, _ := os.UserHomeDir()
+	if home == filepath.Dir(home) || (userHome != "" && home == filepath.Clean(userHome)) {
+		return fmt.Errorf("refusing to use %s as home_dir: setup deletes it", home)
+	}
+	return nil
+}
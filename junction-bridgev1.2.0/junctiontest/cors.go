@@ -0,0 +1,75 @@
+package junctiontest
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SetAPICORSAllowedOrigins enables CORS so browser frontends can reach the
+// node. The API server in app.toml only has an on/off switch
+// (enabled-unsafe-cors, which allows any origin); the origin list itself is
+// CometBFT's rpc.cors_allowed_origins in config.toml.
+func SetAPICORSAllowedOrigins(homeDir string, origins []string) error {
+	if err := setTomlValues(filepath.Join(homeDir, "config", "app.toml"), map[string]interface{}{
+		"api.enabled-unsafe-cors": true,
+	}); err != nil {
+		return err
+	}
+	return setTomlValues(filepath.Join(homeDir, "config", "config.toml"), map[string]interface{}{
+		"rpc.cors_allowed_origins": origins,
+	})
+}
+
+// CheckCORSHeaders sends a GET with an Origin header to url and returns an
+// error unless the response allows origin.
+func CheckCORSHeaders(url, origin string) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Origin", origin)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error requesting %s: %v", url, err)
+	}
+	resp.Body.Close()
+
+	allowed := resp.Header.Get("Access-Control-Allow-Origin")
+	if allowed != "*" && allowed != origin {
+		return fmt.Errorf("%s: Access-Control-Allow-Origin is %q, want %q or \"*\"", url, allowed, origin)
+	}
+	fmt.Printf("✅ %s allows origin %s\n", url, origin)
+	return nil
+}
+
+// ScenarioAPICORS starts a chain with CORSOrigins set (http://localhost:3000
+// if empty) and checks both the REST API and the RPC answer a browser request
+// from the first origin with CORS headers.
+func ScenarioAPICORS(cfg *ChainConfig) error {
+	corsCfg := *cfg
+	if len(corsCfg.CORSOrigins) == 0 {
+		corsCfg.CORSOrigins = []string{"http://localhost:3000"}
+	}
+	if err := SetupChain(&corsCfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(&corsCfg); err != nil {
+		return err
+	}
+	// The API server starts shortly after the first block
+	if err := WaitForHeight(corsCfg.RPCEndpoint, 3, 30*time.Second); err != nil {
+		return err
+	}
+
+	origin := corsCfg.CORSOrigins[0]
+	if origin == "*" {
+		origin = "http://localhost:3000"
+	}
+	if err := CheckCORSHeaders(strings.TrimRight(corsCfg.RestEndpoint, "/")+"/cosmos/base/tendermint/v1beta1/node_info", origin); err != nil {
+		return err
+	}
+	return CheckCORSHeaders(strings.TrimRight(corsCfg.RPCEndpoint, "/")+"/status", origin)
+}
@@ -0,0 +1,168 @@
+package junctiontest
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"time"
+)
+
+// Gov periods used by ScenarioDepositRefundPolicy so a deposit period can
+// expire and a voting period end within the scenario.
+const (
+	refundDepositPeriod = 30 * time.Second
+	refundVotingPeriod  = 60 * time.Second
+)
+
+// ScenarioDepositRefundPolicy checks the chain honors the gov deposit burn
+// params, once with burning on and once with it off. In each round one
+// proposal is left below the minimum deposit until its deposit period
+// expires (burn_proposal_deposit_prevote) and one reaches the voting period
+// but gets no votes, failing quorum (burn_vote_quorum). Each proposal has its
+// own depositor funded with exactly its deposit plus fees, so the depositor
+// ends with the deposit if it was refunded and nothing if it was burned.
+func ScenarioDepositRefundPolicy(cfg *ChainConfig) error {
+	for _, burn := range []bool{true, false} {
+		fmt.Printf("\n🔥 Deposit refund round with burn params set to %t\n", burn)
+		if err := depositRefundRound(cfg, burn); err != nil {
+			return err
+		}
+		if err := Processes.Stop("junctiond"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func depositRefundRound(cfg *ChainConfig, burn bool) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	patches := []GenesisPatch{
+		{Path: []string{"app_state", "gov", "params", "burn_proposal_deposit_prevote"}, Value: burn},
+		{Path: []string{"app_state", "gov", "params", "burn_vote_quorum"}, Value: burn},
+		{Path: []string{"app_state", "gov", "params", "max_deposit_period"}, Value: formatSeconds(refundDepositPeriod)},
+		{Path: []string{"app_state", "gov", "params", "voting_period"}, Value: formatSeconds(refundVotingPeriod)},
+		{Path: []string{"app_state", "gov", "params", "expedited_voting_period"}, Value: formatSeconds(refundVotingPeriod / 2)},
+	}
+	if err := ApplyGenesisPatches(cfg.Home(), patches); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	minDeposit, err := FetchMinDeposit(cfg.RestEndpoint, false)
+	if err != nil {
+		return err
+	}
+	minCoins, err := ParseCoins(minDeposit)
+	if err != nil {
+		return err
+	}
+	minAmount, ok := minCoins[cfg.Denom]
+	if !ok {
+		return fmt.Errorf("minimum deposit %s has no %s", minDeposit, cfg.Denom)
+	}
+	lowDeposit := fmt.Sprintf("%s%s", new(big.Int).Div(minAmount, big.NewInt(2)), cfg.Denom)
+
+	prevoteCfg, prevoteID, err := submitFundedProposal(cfg, "refund-prevote", lowDeposit)
+	if err != nil {
+		return err
+	}
+	quorumCfg, quorumID, err := submitFundedProposal(cfg, "refund-quorum", minDeposit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("⏳ Letting proposal %s miss quorum with no votes...\n", quorumID)
+	info, err := WaitForProposalFinal(cfg.RestEndpoint, quorumID, refundVotingPeriod+time.Minute)
+	if err != nil {
+		return err
+	}
+	if info.Status != "PROPOSAL_STATUS_REJECTED" {
+		return fmt.Errorf("proposal %s with no votes finished as %s, expected PROPOSAL_STATUS_REJECTED", quorumID, info.Status)
+	}
+	// The prevote proposal was submitted first, so its deposit period has
+	// ended too; give the end blocker a few blocks to delete it
+	fmt.Printf("⏳ Waiting for proposal %s's deposit period to expire...\n", prevoteID)
+	time.Sleep(10 * time.Second)
+
+	if err := checkDepositOutcome(prevoteCfg, "burn_proposal_deposit_prevote", lowDeposit, burn); err != nil {
+		return err
+	}
+	return checkDepositOutcome(quorumCfg, "burn_vote_quorum", minDeposit, burn)
+}
+
+// submitFundedProposal funds keyName with exactly deposit plus submit fees
+// and submits a bridge proposal from it carrying deposit, returning the
+// key's config and the proposal ID.
+func submitFundedProposal(cfg *ChainConfig, keyName, deposit string) (*ChainConfig, string, error) {
+	funding, err := ParseCoins(deposit + "," + TxFees(cfg, DefaultSubmitFees))
+	if err != nil {
+		return nil, "", err
+	}
+	if err := EnsureKey(cfg, keyName); err != nil {
+		return nil, "", err
+	}
+	address, err := KeyAddress(cfg, keyName)
+	if err != nil {
+		return nil, "", fmt.Errorf("error looking up %s address: %v", keyName, err)
+	}
+	sendResponse, err := BankSend(cfg, address, FormatCoins(funding))
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := WaitForTx(cfg, sendResponse.TxHash, 30*time.Second); err != nil {
+		return nil, "", err
+	}
+
+	keyCfg := *cfg
+	keyCfg.KeyName = keyName
+	proposal := NewBridgeProposal("", false)
+	proposal.Deposit = deposit
+	txResponse, err := SubmitProposal(&keyCfg, proposal, filepath.Join(cfg.Home(), keyName+"_proposal.json"))
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := WaitForTx(cfg, txResponse.TxHash, 30*time.Second)
+	if err != nil {
+		return nil, "", err
+	}
+	proposalID, err := ProposalIDFromTx(result)
+	if err != nil {
+		return nil, "", err
+	}
+	fmt.Printf("📨 %s submitted proposal %s with a %s deposit\n", keyName, proposalID, deposit)
+	return &keyCfg, proposalID, nil
+}
+
+// checkDepositOutcome checks the depositor keyCfg.KeyName got deposit back,
+// or nothing if param (set to burn) burned it.
+func checkDepositOutcome(keyCfg *ChainConfig, param, deposit string, burn bool) error {
+	address, err := KeyAddress(keyCfg, keyCfg.KeyName)
+	if err != nil {
+		return fmt.Errorf("error looking up %s address: %v", keyCfg.KeyName, err)
+	}
+	balances, err := QueryBalances(keyCfg, address)
+	if err != nil {
+		return err
+	}
+	expected := map[string]*big.Int{}
+	if !burn {
+		if expected, err = ParseCoins(deposit); err != nil {
+			return err
+		}
+	}
+
+	have, want := FormatCoins(balances), FormatCoins(expected)
+	if have != want {
+		return fmt.Errorf("with %s=%t, %s has %q after its proposal ended, expected %q", param, burn, keyCfg.KeyName, have, want)
+	}
+	outcome := "refunded"
+	if burn {
+		outcome = "burned"
+	}
+	fmt.Printf("✅ %s=%t: %s deposit of %s was %s\n", param, burn, keyCfg.KeyName, deposit, outcome)
+	return nil
+}
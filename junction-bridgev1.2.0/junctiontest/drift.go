@@ -0,0 +1,70 @@
+package junctiontest
+
+import (
+	"fmt"
+	"time"
+)
+
+// DriftItem is a chain parameter whose live value differs from what the
+// config expects.
+type DriftItem struct {
+	Parameter string
+	Expected  string
+	Actual    string
+}
+
+// DetectConfigDrift compares live chain parameters against expected: the
+// chain ID reported by the node at rpcURL, the staking bond denom, and the
+// gov periods ModifyGenesisFile sets (queried from expected.RestEndpoint).
+// It returns one item per mismatch.
+func DetectConfigDrift(rpcURL string, expected *ChainConfig) ([]DriftItem, error) {
+	var status struct {
+		Result struct {
+			NodeInfo struct {
+				Network string `json:"network"`
+			} `json:"node_info"`
+		} `json:"result"`
+	}
+	if err := getJSON(rpcURL+"/status", &status); err != nil {
+		return nil, fmt.Errorf("%w: error fetching node status: %v", ErrChainNotReady, err)
+	}
+
+	var staking struct {
+		Params struct {
+			BondDenom string `json:"bond_denom"`
+		} `json:"params"`
+	}
+	if err := getJSON(expected.RestEndpoint+"/cosmos/staking/v1beta1/params", &staking); err != nil {
+		return nil, fmt.Errorf("error fetching staking params: %v", err)
+	}
+
+	var gov struct {
+		Params struct {
+			MaxDepositPeriod      string `json:"max_deposit_period"`
+			VotingPeriod          string `json:"voting_period"`
+			ExpeditedVotingPeriod string `json:"expedited_voting_period"`
+		} `json:"params"`
+	}
+	if err := getJSON(expected.RestEndpoint+"/cosmos/gov/v1/params/voting", &gov); err != nil {
+		return nil, fmt.Errorf("error fetching gov params: %v", err)
+	}
+
+	var drift []DriftItem
+	compare := func(parameter, want, got string) {
+		if want != got {
+			drift = append(drift, DriftItem{Parameter: parameter, Expected: want, Actual: got})
+		}
+	}
+	compareDuration := func(parameter string, want time.Duration, got string) {
+		if d, err := time.ParseDuration(got); err != nil || d != want {
+			drift = append(drift, DriftItem{Parameter: parameter, Expected: want.String(), Actual: got})
+		}
+	}
+
+	compare("chain_id", expected.ChainID, status.Result.NodeInfo.Network)
+	compare("staking.bond_denom", expected.Denom, staking.Params.BondDenom)
+	compareDuration("gov.max_deposit_period", MaxDepositPeriod, gov.Params.MaxDepositPeriod)
+	compareDuration("gov.voting_period", VotingPeriod, gov.Params.VotingPeriod)
+	compareDuration("gov.expedited_voting_period", ExpeditedVotingPeriod, gov.Params.ExpeditedVotingPeriod)
+	return drift, nil
+}
@@ -0,0 +1,96 @@
+package junctiontest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// EnduranceStats summarizes an EnduranceTestLoop run.
+type EnduranceStats struct {
+	Proposals    int
+	Failures     int
+	TotalElapsed time.Duration
+	CycleTotal   time.Duration
+}
+
+// FailureRate is the fraction of proposals that did not pass.
+func (s EnduranceStats) FailureRate() float64 {
+	if s.Proposals == 0 {
+		return 0
+	}
+	return float64(s.Failures) / float64(s.Proposals)
+}
+
+// AverageCycle is the mean time from submission to final outcome.
+func (s EnduranceStats) AverageCycle() time.Duration {
+	if s.Proposals == 0 {
+		return 0
+	}
+	return s.CycleTotal / time.Duration(s.Proposals)
+}
+
+func (s EnduranceStats) String() string {
+	return fmt.Sprintf("%d proposals, %d failed (%.1f%%), average cycle %s, total %s",
+		s.Proposals, s.Failures, s.FailureRate()*100, s.AverageCycle().Round(time.Second), s.TotalElapsed.Round(time.Second))
+}
+
+// EnduranceTestLoop passes bridge proposals back to back (submit, vote yes,
+// wait for the outcome) until duration has elapsed or ctx is cancelled.
+// Cancelling ctx also aborts the cycle in flight, which is not counted.
+// Failed proposals are counted and the loop carries on; it stops early only
+// if the node at rpcURL becomes unreachable. The error reports any failures.
+func EnduranceTestLoop(ctx context.Context, rpcURL string, duration time.Duration, cfg *ChainConfig) (*EnduranceStats, error) {
+	stats := &EnduranceStats{}
+	start := time.Now()
+	deadline := start.Add(duration)
+	proposalPath := filepath.Join(cfg.Home(), "endurance_proposal.json")
+
+	for time.Now().Before(deadline) {
+		if ctx.Err() != nil {
+			fmt.Println("🛑 Endurance loop cancelled")
+			break
+		}
+		if state, err := QuerySyncState(rpcURL); err != nil || state != SyncStateSynced {
+			stats.TotalElapsed = time.Since(start)
+			return stats, fmt.Errorf("%w: node at %s is %s after %d proposals", ErrChainNotReady, rpcURL, state, stats.Proposals)
+		}
+
+		cycleStart := time.Now()
+		fmt.Printf("\n🔁 Endurance cycle %d (%s left)\n", stats.Proposals+1, time.Until(deadline).Round(time.Second))
+		proposalID, err := PassProposal(ctx, cfg, NewBridgeProposal("", cfg.Expedited), proposalPath)
+		if ctx.Err() != nil {
+			fmt.Println("🛑 Endurance loop cancelled")
+			break
+		}
+		stats.Proposals++
+		stats.CycleTotal += time.Since(cycleStart)
+		if err != nil {
+			stats.Failures++
+			fmt.Printf("❌ Cycle %d (proposal %s) failed: %v\n", stats.Proposals, proposalID, err)
+		} else {
+			fmt.Printf("✅ Proposal %s passed in %s\n", proposalID, time.Since(cycleStart).Round(time.Second))
+		}
+	}
+
+	stats.TotalElapsed = time.Since(start)
+	fmt.Printf("\n📊 Endurance: %s\n", stats)
+	if stats.Failures > 0 {
+		return stats, fmt.Errorf("%d of %d endurance proposals failed", stats.Failures, stats.Proposals)
+	}
+	return stats, nil
+}
+
+// ScenarioEndurance runs EnduranceTestLoop against a fresh chain for
+// EnduranceDuration.
+func ScenarioEndurance(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+	_, err := EnduranceTestLoop(context.Background(), cfg.RPCEndpoint, cfg.EnduranceDuration, cfg)
+	return err
+}
@@ -0,0 +1,117 @@
+package junctiontest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors returned (wrapped) by this package so callers can branch
+// with errors.Is.
+var (
+	// ErrMissingDependency means a required binary (junctiond, hermes) could
+	// not be found.
+	ErrMissingDependency = errors.New("missing dependency")
+	// ErrVersionMismatch means the junctiond binary differs from the pinned
+	// version.
+	ErrVersionMismatch = errors.New("junctiond version mismatch")
+	// ErrConfigDrift means live chain parameters differ from the config.
+	ErrConfigDrift = errors.New("chain config drift")
+	// ErrChecksumMismatch means the junctiond binary's SHA-256 differs from
+	// the configured one.
+	ErrChecksumMismatch = errors.New("junctiond checksum mismatch")
+	// ErrChainNotReady means the node did not start, is unreachable, did
+	// not sync in time or crashed.
+	ErrChainNotReady = errors.New("chain not ready")
+	// ErrProposalRejected means a proposal finished without passing.
+	ErrProposalRejected = errors.New("proposal rejected")
+	// ErrDepositTooLow means the chain refused a proposal deposit as below
+	// the minimum.
+	ErrDepositTooLow = errors.New("deposit too low")
+	// ErrInsufficientBalance means the proposer cannot cover a deposit plus
+	// fees.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrValidatorSetChanged means the validator set changed while a
+	// proposal was in flight.
+	ErrValidatorSetChanged = errors.New("validator set changed")
+	// ErrInvalidAddress means an address in a tx or proposal could not be
+	// decoded.
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrTxFailed means a broadcast tx returned a non-zero code.
+	ErrTxFailed = errors.New("transaction failed")
+	// ErrExpectationFailed means a finished proposal did not match the
+	// configured expected status or yes percentage.
+	ErrExpectationFailed = errors.New("expectation failed")
+	// ErrProposalNotCancelable means a proposal cannot be cancelled: it is
+	// past its voting period, was submitted by another account, or the
+	// binary has no cancel-proposal command.
+	ErrProposalNotCancelable = errors.New("proposal not cancelable")
+)
+
+// txFailure builds the error for a tx that returned a non-zero code. It wraps
+// a *TxError for errors.As, plus a more specific sentinel when the raw log
+// identifies the cause.
+func txFailure(txHash, codespace string, code uint32, rawLog string) error {
+	txErr := ParseTxError(rawLog)
+	if txErr.Codespace == "" && codespace != "" {
+		txErr = ParseTxError(fmt.Sprintf("codespace %s code %d: %s", codespace, code, rawLog))
+		txErr.RawLog = rawLog
+	}
+	err := fmt.Errorf("%w: tx %s: %w", ErrTxFailed, txHash, &txErr)
+
+	lower := strings.ToLower(rawLog)
+	switch {
+	case strings.Contains(lower, "minimum deposit"),
+		strings.Contains(lower, "insufficient deposit"),
+		strings.Contains(lower, "deposit is too low"):
+		return fmt.Errorf("%w: %w", ErrDepositTooLow, err)
+	case strings.Contains(lower, "invalid address"),
+		strings.Contains(lower, "decoding bech32 failed"):
+		return fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+	return err
+}
+
+// CheckProposalOutcome returns nil if the proposal passed, an error wrapping
+// ErrProposalRejected if it was rejected or failed, and a plain error if it
+// has not finished yet.
+func CheckProposalOutcome(info *ProposalInfo) error {
+	switch info.Status {
+	case "PROPOSAL_STATUS_PASSED":
+		return nil
+	case "PROPOSAL_STATUS_REJECTED", "PROPOSAL_STATUS_FAILED":
+		return fmt.Errorf("%w: proposal %s finished with %s", ErrProposalRejected, info.ID, info.Status)
+	default:
+		return fmt.Errorf("proposal %s has not finished (status %s)", info.ID, info.Status)
+	}
+}
+
+// YesPercentage returns the yes votes as a percentage of all votes in the
+// proposal's final tally, or 0 if nobody voted.
+func YesPercentage(info *ProposalInfo) float64 {
+	tally := info.FinalTallyResult
+	yes := parseAmount(tally.YesCount)
+	total := yes + parseAmount(tally.NoCount) + parseAmount(tally.AbstainCount) + parseAmount(tally.NoWithVetoCount)
+	if total == 0 {
+		return 0
+	}
+	return yes / total * 100
+}
+
+// CheckProposalExpectations compares a finished proposal with the expected
+// status (e.g. "PASSED" or "PROPOSAL_STATUS_REJECTED"; empty to skip) and
+// minimum yes percentage (0 to skip), returning an error wrapping
+// ErrExpectationFailed that lists every mismatch.
+func CheckProposalExpectations(info *ProposalInfo, expectStatus string, yesMin float64) error {
+	var mismatches []string
+	if expected := NormalizeProposalStatus(expectStatus); expected != "" && info.Status != expected {
+		mismatches = append(mismatches, fmt.Sprintf("status is %s, expected %s", info.Status, expected))
+	}
+	if yes := YesPercentage(info); yesMin > 0 && yes < yesMin {
+		mismatches = append(mismatches, fmt.Sprintf("yes votes are %.2f%% of the tally, expected at least %.2f%%", yes, yesMin))
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: proposal %s: %s", ErrExpectationFailed, info.ID, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
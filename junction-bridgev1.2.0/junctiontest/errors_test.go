@@ -0,0 +1,67 @@
+package junctiontest
+
+import (
+	"errors"
+	"testing"
+)
+
+func tallyProposal(status, yes, no, abstain, veto string) *ProposalInfo {
+	info := &ProposalInfo{ID: "1", Status: status}
+	info.FinalTallyResult.YesCount = yes
+	info.FinalTallyResult.NoCount = no
+	info.FinalTallyResult.AbstainCount = abstain
+	info.FinalTallyResult.NoWithVetoCount = veto
+	return info
+}
+
+func TestYesPercentage(t *testing.T) {
+	tests := []struct {
+		name string
+		info *ProposalInfo
+		want float64
+	}{
+		{"no votes", tallyProposal("PROPOSAL_STATUS_PASSED", "0", "0", "0", "0"), 0},
+		{"empty tally", tallyProposal("PROPOSAL_STATUS_PASSED", "", "", "", ""), 0},
+		{"all yes", tallyProposal("PROPOSAL_STATUS_PASSED", "1000", "0", "0", "0"), 100},
+		{"abstain and veto count", tallyProposal("PROPOSAL_STATUS_PASSED", "50", "20", "20", "10"), 50},
+		{"large amounts", tallyProposal("PROPOSAL_STATUS_PASSED", "30000000000000000000", "10000000000000000000", "0", "0"), 75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := YesPercentage(tt.info); got != tt.want {
+				t.Errorf("YesPercentage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckProposalExpectations(t *testing.T) {
+	passed := tallyProposal("PROPOSAL_STATUS_PASSED", "80", "20", "0", "0")
+	rejected := tallyProposal("PROPOSAL_STATUS_REJECTED", "10", "90", "0", "0")
+	tests := []struct {
+		name         string
+		info         *ProposalInfo
+		expectStatus string
+		yesMin       float64
+		wantErr      bool
+	}{
+		{"no expectations", rejected, "", 0, false},
+		{"short status matches", passed, "passed", 0, false},
+		{"full status matches", rejected, "PROPOSAL_STATUS_REJECTED", 0, false},
+		{"status differs", rejected, "PASSED", 0, true},
+		{"yes percentage met", passed, "", 80, false},
+		{"yes percentage below minimum", passed, "", 80.5, true},
+		{"both differ", rejected, "PASSED", 50, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckProposalExpectations(tt.info, tt.expectStatus, tt.yesMin)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckProposalExpectations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrExpectationFailed) {
+				t.Errorf("CheckProposalExpectations() error = %v, want it to wrap ErrExpectationFailed", err)
+			}
+		})
+	}
+}
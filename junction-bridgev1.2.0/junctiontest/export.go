@@ -0,0 +1,245 @@
+package junctiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Ports used by the throwaway chain started during export verification, so
+// it does not collide with a node using the default ports.
+const (
+	verifyRPCAddr = "tcp://127.0.0.1:36657"
+	verifyRPCURL  = "http://127.0.0.1:36657"
+	verifyP2PAddr = "tcp://127.0.0.1:36656"
+)
+
+type StatusResponse struct {
+	Result struct {
+		NodeInfo struct {
+			Network string `json:"network"`
+		} `json:"node_info"`
+		SyncInfo struct {
+			LatestBlockHeight string `json:"latest_block_height"`
+			CatchingUp        bool   `json:"catching_up"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+// ExportChainState writes the chain state as genesis JSON to exportPath. The
+// node must be stopped, since export needs exclusive access to the database.
+func ExportChainState(exportPath string, cfg *ChainConfig) error {
+	return ExportChainStateAtHeight(exportPath, cfg, 0)
+}
+
+// ExportChainStateAtHeight is ExportChainState for the state as of height; 0
+// exports the latest state.
+func ExportChainStateAtHeight(exportPath string, cfg *ChainConfig, height int64) error {
+	homeDir := cfg.Home()
+
+	out, err := os.Create(exportPath)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %v", exportPath, err)
+	}
+	defer out.Close()
+
+	args := []string{"export", "--home", homeDir}
+	if height > 0 {
+		args = append(args, "--height", strconv.FormatInt(height, 10))
+	}
+	exportCmd := JunctiondCommand(cfg, args...)
+	exportCmd.Stdout = out
+	exportCmd.Stderr = os.Stderr
+	if err := exportCmd.Run(); err != nil {
+		return fmt.Errorf("error running junctiond export: %v", err)
+	}
+	return nil
+}
+
+// VerifyExportedStateIntegrity starts a temporary chain using exportPath as its
+// genesis, waits for it to commit a block and stops it again. The temporary
+// home reuses the validator and node keys from cfg.HomeDir so the exported
+// validator set can sign blocks.
+func VerifyExportedStateIntegrity(exportPath string, cfg *ChainConfig) error {
+	data, err := os.ReadFile(exportPath)
+	if err != nil {
+		return fmt.Errorf("error reading exported state: %v", err)
+	}
+
+	var exported struct {
+		ChainID       string `json:"chain_id"`
+		InitialHeight string `json:"initial_height"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return fmt.Errorf("exported state is not valid JSON: %v", err)
+	}
+
+	initialHeight := int64(1)
+	if exported.InitialHeight != "" {
+		initialHeight, err = strconv.ParseInt(exported.InitialHeight, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid initial_height %q: %v", exported.InitialHeight, err)
+		}
+	}
+
+	verifyHome, err := newTempHome(cfg, data)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(verifyHome)
+
+	startCmd, err := startTempChain(cfg, verifyHome)
+	if err != nil {
+		return fmt.Errorf("error starting verification chain: %v", err)
+	}
+	defer func() {
+		startCmd.Process.Signal(os.Interrupt)
+		startCmd.Wait()
+	}()
+
+	fmt.Printf("⏳ Waiting for block %d on verification chain %s...\n", initialHeight, exported.ChainID)
+	if err := WaitForHeight(verifyRPCURL, initialHeight, 60*time.Second); err != nil {
+		return fmt.Errorf("verification chain did not produce blocks: %v", err)
+	}
+	return nil
+}
+
+// newTempHome creates a temporary node home with genesis as its genesis file,
+// reusing the validator and node keys from cfg's home so the existing
+// validator set can sign blocks.
+func newTempHome(cfg *ChainConfig, genesis []byte) (string, error) {
+	home, err := os.MkdirTemp("", "junction-verify-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary home: %v", err)
+	}
+
+	if err := copyDir(filepath.Join(cfg.Home(), "config"), filepath.Join(home, "config")); err != nil {
+		os.RemoveAll(home)
+		return "", fmt.Errorf("error copying node config: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(home, "data"), 0755); err != nil {
+		os.RemoveAll(home)
+		return "", fmt.Errorf("error creating data directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "data", "priv_validator_state.json"), []byte(`{"height":"0","round":0,"step":0}`), 0644); err != nil {
+		os.RemoveAll(home)
+		return "", fmt.Errorf("error resetting validator state: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "config", "genesis.json"), genesis, 0644); err != nil {
+		os.RemoveAll(home)
+		return "", fmt.Errorf("error writing genesis: %v", err)
+	}
+	return home, nil
+}
+
+// startTempChain starts a node from home on the alternate verification
+// ports, logging to home/junctiond.log. The caller must stop it.
+func startTempChain(cfg *ChainConfig, home string) (*exec.Cmd, error) {
+	logFile, err := os.Create(filepath.Join(home, "junctiond.log"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating log file: %v", err)
+	}
+
+	startCmd := JunctiondCommand(cfg, "start",
+		"--home", home,
+		"--minimum-gas-prices", cfg.MinimumGasPrices,
+		"--rpc.laddr", verifyRPCAddr,
+		"--p2p.laddr", verifyP2PAddr,
+		"--api.enable=false",
+		"--grpc.enable=false",
+	)
+	startCmd.Stdout = logFile
+	startCmd.Stderr = logFile
+	if err := startCmd.Start(); err != nil {
+		logFile.Close()
+		return nil, err
+	}
+	// The child holds its own descriptor
+	logFile.Close()
+	return startCmd, nil
+}
+
+// FetchStatus queries the CometBFT RPC /status endpoint.
+func FetchStatus(rpcURL string) (*StatusResponse, error) {
+	resp, err := http.Get(rpcURL + "/status")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var status StatusResponse
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// WaitForHeight polls the RPC status endpoint until the latest block height
+// reaches height.
+func WaitForHeight(rpcURL string, height int64, timeout time.Duration) error {
+	return WaitForHeightContext(context.Background(), rpcURL, height, timeout)
+}
+
+// WaitForHeightContext is WaitForHeight that also returns ctx's error as soon
+// as ctx is cancelled.
+func WaitForHeightContext(ctx context.Context, rpcURL string, height int64, timeout time.Duration) error {
+	return waitForHeightBackoff(ctx, rpcURL, height, timeout, Backoff{Initial: time.Second, Max: time.Second})
+}
+
+// waitForHeightBackoff is WaitForHeightContext with polls spaced by backoff.
+func waitForHeightBackoff(ctx context.Context, rpcURL string, height int64, timeout time.Duration, backoff Backoff) error {
+	deadline := time.Now().Add(timeout)
+	for attempt := 0; time.Now().Before(deadline); attempt++ {
+		if err := checkChainAlive(); err != nil {
+			return err
+		}
+
+		status, err := FetchStatus(rpcURL)
+		if err == nil {
+			latest, _ := strconv.ParseInt(status.Result.SyncInfo.LatestBlockHeight, 10, 64)
+			if latest >= height {
+				return nil
+			}
+		}
+		if err := sleepContext(ctx, backoff.Delay(attempt)); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("timed out after %s waiting for height %d", timeout, height)
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode())
+	})
+}
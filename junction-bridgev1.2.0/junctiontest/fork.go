@@ -0,0 +1,124 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ForkChainSuffix is appended to the chain ID of the forked instance.
+const ForkChainSuffix = "-fork"
+
+// FetchBlockAppHash returns the app_hash in the header of the block at
+// height.
+func FetchBlockAppHash(rpcURL string, height int64) (string, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/block?height=%d", rpcURL, height))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var block struct {
+		Result struct {
+			Block struct {
+				Header struct {
+					AppHash string `json:"app_hash"`
+				} `json:"header"`
+			} `json:"block"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &block); err != nil {
+		return "", fmt.Errorf("error parsing block %d: %v", height, err)
+	}
+	if block.Result.Block.Header.AppHash == "" {
+		return "", fmt.Errorf("block %d not found", height)
+	}
+	return block.Result.Block.Header.AppHash, nil
+}
+
+// ForkChainState checks state export/import by forking the running chain at
+// height: it records block height+1, stops the chain, exports the state as of
+// height, starts a second instance from it under a suffixed chain ID, and
+// compares the app hash of that instance's first block (height+1) with the
+// original. The chain must have been started with StartChainBackground; it
+// is left stopped.
+func ForkChainState(cfg *ChainConfig, height int64) error {
+	fmt.Printf("⏳ Waiting for block %d...\n", height+1)
+	if err := WaitForHeight(cfg.RPCEndpoint, height+1, time.Duration(height+1)*10*time.Second+30*time.Second); err != nil {
+		return err
+	}
+	originalHash, err := FetchBlockAppHash(cfg.RPCEndpoint, height+1)
+	if err != nil {
+		return fmt.Errorf("error reading original block %d: %v", height+1, err)
+	}
+
+	fmt.Println("🛑 Stopping chain for export...")
+	if err := Processes.Stop("junctiond"); err != nil {
+		return err
+	}
+
+	exportFile, err := os.CreateTemp("", "junction-fork-*.json")
+	if err != nil {
+		return fmt.Errorf("error creating export file: %v", err)
+	}
+	exportFile.Close()
+	defer os.Remove(exportFile.Name())
+
+	fmt.Printf("📦 Exporting state at height %d...\n", height)
+	if err := ExportChainStateAtHeight(exportFile.Name(), cfg, height); err != nil {
+		return err
+	}
+
+	genesis, err := os.ReadFile(exportFile.Name())
+	if err != nil {
+		return fmt.Errorf("error reading exported state: %v", err)
+	}
+	var exported map[string]interface{}
+	if err := json.Unmarshal(genesis, &exported); err != nil {
+		return fmt.Errorf("exported state is not valid JSON: %v", err)
+	}
+	forkChainID := cfg.ChainID + ForkChainSuffix
+	exported["chain_id"] = forkChainID
+	genesis, err = json.Marshal(exported)
+	if err != nil {
+		return fmt.Errorf("error marshaling forked genesis: %v", err)
+	}
+
+	forkHome, err := newTempHome(cfg, genesis)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(forkHome)
+
+	fmt.Printf("🍴 Starting fork %s from height %d...\n", forkChainID, height)
+	forkCmd, err := startTempChain(cfg, forkHome)
+	if err != nil {
+		return fmt.Errorf("%w: error starting fork: %v", ErrChainNotReady, err)
+	}
+	defer func() {
+		forkCmd.Process.Signal(os.Interrupt)
+		forkCmd.Wait()
+	}()
+
+	if err := WaitForHeight(verifyRPCURL, height+1, 60*time.Second); err != nil {
+		return fmt.Errorf("%w: fork did not produce block %d: %v", ErrChainNotReady, height+1, err)
+	}
+	forkHash, err := FetchBlockAppHash(verifyRPCURL, height+1)
+	if err != nil {
+		return fmt.Errorf("error reading fork block %d: %v", height+1, err)
+	}
+
+	if forkHash != originalHash {
+		return fmt.Errorf("block %d app hash differs: original %s, fork %s", height+1, originalHash, forkHash)
+	}
+	fmt.Printf("✅ Block %d app hash matches on both instances: %s\n", height+1, forkHash)
+	return nil
+}
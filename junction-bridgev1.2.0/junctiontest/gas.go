@@ -0,0 +1,133 @@
+package junctiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Gas is the profiler that the submit, deposit and vote txs this package
+// waits on are recorded in. It is nil, and nothing is recorded, unless the
+// caller sets it.
+var Gas *GasProfiler
+
+// GasProfiler records the gas used by governance transactions, keyed by
+// operation (submit, deposit, vote), across runs.
+type GasProfiler struct {
+	Records map[string][]int64 `json:"records"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// LoadGasProfiler reads the profile stored at path, returning an empty
+// profiler if the file does not exist yet.
+func LoadGasProfiler(path string) (*GasProfiler, error) {
+	profiler := &GasProfiler{Records: map[string][]int64{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return profiler, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, profiler); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	if profiler.Records == nil {
+		profiler.Records = map[string][]int64{}
+	}
+	return profiler, nil
+}
+
+// Save writes the profile back to the file it was loaded from.
+func (p *GasProfiler) Save() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling gas profile: %v", err)
+	}
+	if err := WriteFileAtomic(p.path, data); err != nil {
+		return fmt.Errorf("error writing %s: %v", p.path, err)
+	}
+	return nil
+}
+
+// Track records the gas used by an included tx under operation.
+func (p *GasProfiler) Track(operation string, result *TxResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Records[operation] = append(p.Records[operation], result.GasUsed)
+}
+
+// WaitForTx waits for txHash like the package-level WaitForTx and records
+// the gas it used under operation. Failed txs are recorded too, since they
+// still used gas. A nil profiler only waits.
+func (p *GasProfiler) WaitForTx(cfg *ChainConfig, operation, txHash string, timeout time.Duration) (*TxResult, error) {
+	return p.WaitForTxContext(context.Background(), cfg, operation, txHash, timeout)
+}
+
+// WaitForTxContext is WaitForTx that also returns ctx's error as soon as ctx
+// is cancelled.
+func (p *GasProfiler) WaitForTxContext(ctx context.Context, cfg *ChainConfig, operation, txHash string, timeout time.Duration) (*TxResult, error) {
+	result, err := WaitForTxContext(ctx, cfg, txHash, timeout)
+	if p != nil && result != nil {
+		p.Track(operation, result)
+	}
+	return result, err
+}
+
+// PrintReport prints min/max/avg gas per operation along with a suggested
+// gas adjustment derived from the spread between average and peak usage.
+func (p *GasProfiler) PrintReport() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Println("\n⛽ Gas Usage Report")
+	fmt.Println("==================")
+
+	if len(p.Records) == 0 {
+		fmt.Println("No gas usage recorded yet")
+		return
+	}
+
+	operations := make([]string, 0, len(p.Records))
+	for operation := range p.Records {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	fmt.Printf("%-10s %6s %10s %10s %10s %12s\n", "OPERATION", "COUNT", "MIN", "MAX", "AVG", "ADJUSTMENT")
+	for _, operation := range operations {
+		samples := p.Records[operation]
+		if len(samples) == 0 {
+			continue
+		}
+
+		minGas, maxGas, total := samples[0], samples[0], int64(0)
+		for _, gas := range samples {
+			if gas < minGas {
+				minGas = gas
+			}
+			if gas > maxGas {
+				maxGas = gas
+			}
+			total += gas
+		}
+		avg := total / int64(len(samples))
+
+		// Enough headroom over the average estimate to cover the worst
+		// observed run, plus a 10% safety margin.
+		adjustment := 1.1
+		if avg > 0 {
+			adjustment = float64(maxGas) / float64(avg) * 1.1
+		}
+
+		fmt.Printf("%-10s %6d %10d %10d %10d %12.2f\n", operation, len(samples), minGas, maxGas, avg, adjustment)
+	}
+}
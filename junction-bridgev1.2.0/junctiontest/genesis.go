@@ -0,0 +1,272 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Gov periods written to genesis by ModifyGenesisFile.
+const (
+	MaxDepositPeriod      = 600 * time.Second
+	VotingPeriod          = 660 * time.Second
+	ExpeditedVotingPeriod = 300 * time.Second
+)
+
+// formatSeconds renders d the way genesis durations are written, e.g. "660s".
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d/time.Second))
+}
+
+type GenesisConfig struct {
+	AppState struct {
+		Gov struct {
+			Params struct {
+				MaxDepositPeriod      string `json:"max_deposit_period"`
+				VotingPeriod          string `json:"voting_period"`
+				ExpeditedVotingPeriod string `json:"expedited_voting_period"`
+			} `json:"params"`
+		} `json:"gov"`
+	} `json:"app_state"`
+}
+
+// GenesisPatch sets the value at Path, a list of object keys from the root
+// of genesis.json (e.g. ["app_state", "gov", "params", "voting_period"]).
+type GenesisPatch struct {
+	Path  []string
+	Value interface{}
+}
+
+// ModifyGenesisFile shortens the governance deposit and voting periods so
+// proposals complete within a test run.
+func ModifyGenesisFile(homeDir string) error {
+	patches := []GenesisPatch{
+		{Path: []string{"app_state", "gov", "params", "max_deposit_period"}, Value: formatSeconds(MaxDepositPeriod)},
+		{Path: []string{"app_state", "gov", "params", "voting_period"}, Value: formatSeconds(VotingPeriod)},
+		{Path: []string{"app_state", "gov", "params", "expedited_voting_period"}, Value: formatSeconds(ExpeditedVotingPeriod)},
+	}
+	if err := ApplyGenesisPatches(homeDir, patches); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Genesis file updated with new voting and deposit periods")
+	return nil
+}
+
+// MintGenesisPatches returns the patches for the x/mint settings in cfg
+// (the minter's starting inflation and the inflation bounds, rate change and
+// bonded goal params), skipping unset ones. Each must be a decimal between 0
+// and 1, and inflation_min may not exceed inflation_max.
+func MintGenesisPatches(cfg *ChainConfig) ([]GenesisPatch, error) {
+	settings := []struct {
+		name  string
+		value string
+		path  []string
+	}{
+		{"genesis_inflation", cfg.GenesisInflation, []string{"app_state", "mint", "minter", "inflation"}},
+		{"genesis_inflation_min", cfg.GenesisInflationMin, []string{"app_state", "mint", "params", "inflation_min"}},
+		{"genesis_inflation_max", cfg.GenesisInflationMax, []string{"app_state", "mint", "params", "inflation_max"}},
+		{"genesis_inflation_rate_change", cfg.GenesisInflationRateChange, []string{"app_state", "mint", "params", "inflation_rate_change"}},
+		{"genesis_goal_bonded", cfg.GenesisGoalBonded, []string{"app_state", "mint", "params", "goal_bonded"}},
+	}
+
+	var patches []GenesisPatch
+	for _, setting := range settings {
+		if setting.value == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(setting.value, 64)
+		if err != nil || value < 0 || value > 1 {
+			return nil, fmt.Errorf("invalid %s %q (expected a decimal between 0 and 1, e.g. 0.13)", setting.name, setting.value)
+		}
+		patches = append(patches, GenesisPatch{Path: setting.path, Value: setting.value})
+	}
+
+	if cfg.GenesisInflationMin != "" && cfg.GenesisInflationMax != "" {
+		inflationMin, _ := strconv.ParseFloat(cfg.GenesisInflationMin, 64)
+		inflationMax, _ := strconv.ParseFloat(cfg.GenesisInflationMax, 64)
+		if inflationMin > inflationMax {
+			return nil, fmt.Errorf("genesis_inflation_min %s is above genesis_inflation_max %s", cfg.GenesisInflationMin, cfg.GenesisInflationMax)
+		}
+	}
+	return patches, nil
+}
+
+// InstallGenesisFile copies cfg.GenesisFile over the node's genesis.json
+// after checking that it is valid JSON and was made for cfg.ChainID. The
+// copied genesis is used as is; no keys, accounts or gentxs are added.
+func InstallGenesisFile(cfg *ChainConfig, homeDir string) error {
+	data, err := os.ReadFile(cfg.GenesisFile)
+	if err != nil {
+		return fmt.Errorf("error reading genesis file %s: %v", cfg.GenesisFile, err)
+	}
+
+	var genesis struct {
+		ChainID string `json:"chain_id"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return fmt.Errorf("error parsing genesis file %s: %v", cfg.GenesisFile, err)
+	}
+	if genesis.ChainID != cfg.ChainID {
+		return fmt.Errorf("genesis file %s is for chain_id %q, expected %q", cfg.GenesisFile, genesis.ChainID, cfg.ChainID)
+	}
+
+	if err := os.WriteFile(filepath.Join(homeDir, "config", "genesis.json"), data, 0644); err != nil {
+		return fmt.Errorf("error writing genesis file: %v", err)
+	}
+	fmt.Printf("✅ Genesis installed from %s\n", cfg.GenesisFile)
+	return nil
+}
+
+// ApplyGenesisPatches applies patches to the node's genesis.json using only
+// encoding/json, so no external tools such as jq are needed. Every object on
+// a patch's path except the last key must already exist.
+func ApplyGenesisPatches(homeDir string, patches []GenesisPatch) error {
+	genesisFile := filepath.Join(homeDir, "config", "genesis.json")
+
+	data, err := os.ReadFile(genesisFile)
+	if err != nil {
+		return fmt.Errorf("error reading genesis file: %v", err)
+	}
+
+	var genesis map[string]interface{}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return fmt.Errorf("error parsing genesis file: %v", err)
+	}
+
+	for _, patch := range patches {
+		if err := setPath(genesis, patch.Path, patch.Value); err != nil {
+			return fmt.Errorf("error patching %s: %v", strings.Join(patch.Path, "."), err)
+		}
+	}
+
+	updatedData, err := json.MarshalIndent(genesis, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling updated genesis: %v", err)
+	}
+	if err := os.WriteFile(genesisFile, updatedData, 0644); err != nil {
+		return fmt.Errorf("error writing updated genesis file: %v", err)
+	}
+	return nil
+}
+
+// setPath walks obj along path and sets the final key to value.
+func setPath(obj map[string]interface{}, path []string, value interface{}) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+	if len(path) == 1 {
+		obj[path[0]] = value
+		return nil
+	}
+
+	child, ok := obj[path[0]].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s not found or not an object", path[0])
+	}
+	return setPath(child, path[1:], value)
+}
+
+// ModifyAppTomlFile sets the minimum gas price and enables the API server
+// and swagger in app.toml.
+func ModifyAppTomlFile(homeDir string) error {
+	appTomlFile := filepath.Join(homeDir, "config", "app.toml")
+
+	// Read the app.toml file
+	data, err := os.ReadFile(appTomlFile)
+	if err != nil {
+		return fmt.Errorf("error reading app.toml file: %v", err)
+	}
+
+	content := string(data)
+
+	// Apply modifications
+	content = strings.ReplaceAll(content, `minimum-gas-prices = ""`, `minimum-gas-prices = "0.00025uamf"`)
+	content = strings.ReplaceAll(content, `enable = false`, `enable = true`)
+	content = strings.ReplaceAll(content, `swagger = false`, `swagger = true`)
+
+	// Write back to file
+	if err := os.WriteFile(appTomlFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("error writing updated app.toml file: %v", err)
+	}
+
+	fmt.Println("✅ App.toml file updated with new minimum gas prices")
+	return nil
+}
+
+// ReadGovParams returns app_state.gov.params from the node's genesis file.
+func ReadGovParams(homeDir string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(filepath.Join(homeDir, "config", "genesis.json"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading genesis file: %v", err)
+	}
+
+	var genesis struct {
+		AppState struct {
+			Gov struct {
+				Params map[string]interface{} `json:"params"`
+			} `json:"gov"`
+		} `json:"app_state"`
+	}
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		return nil, fmt.Errorf("error parsing genesis file: %v", err)
+	}
+	return genesis.AppState.Gov.Params, nil
+}
+
+// PrintParamDiff prints the keys whose values differ between before and
+// after, old values in red and new values in green. Colors are omitted when
+// NO_COLOR is set.
+func PrintParamDiff(title string, before, after map[string]interface{}) {
+	red, green, reset := "\033[31m", "\033[32m", "\033[0m"
+	if os.Getenv("NO_COLOR") != "" {
+		red, green, reset = "", "", ""
+	}
+
+	keys := map[string]bool{}
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	fmt.Printf("🔍 Changes to %s:\n", title)
+	changed := false
+	for _, key := range sorted {
+		old, new := formatParam(before, key), formatParam(after, key)
+		if old == new {
+			continue
+		}
+		changed = true
+		fmt.Printf("   %s: %s%s%s → %s%s%s\n", key, red, old, reset, green, new, reset)
+	}
+	if !changed {
+		fmt.Println("   (no changes)")
+	}
+}
+
+func formatParam(params map[string]interface{}, key string) string {
+	value, ok := params[key]
+	if !ok {
+		return "<unset>"
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
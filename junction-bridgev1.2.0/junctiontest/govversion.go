@@ -0,0 +1,137 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Gov module versions, as detected by DetectGovVersion or set in
+// ChainConfig.GovVersion.
+const (
+	GovVersionAuto    = "auto"
+	GovVersionV1      = "v1"
+	GovVersionV1Beta1 = "v1beta1"
+)
+
+var (
+	govVersionsMu sync.Mutex
+	govVersions   = map[string]string{}
+)
+
+// DetectGovVersion reports which gov submit command the junctiond binary
+// speaks: GovVersionV1 (`tx gov submit-proposal <file>` with messages) or
+// GovVersionV1Beta1 (`tx gov submit-proposal --type ...`). It reads the
+// command's help output, so it works without a running chain, and remembers
+// the answer per binary. A GovVersion other than "auto" is returned as is.
+func DetectGovVersion(cfg *ChainConfig) (string, error) {
+	switch cfg.GovVersion {
+	case GovVersionV1, GovVersionV1Beta1:
+		return cfg.GovVersion, nil
+	case "", GovVersionAuto:
+	default:
+		return "", fmt.Errorf("invalid gov_version %q (expected auto, v1 or v1beta1)", cfg.GovVersion)
+	}
+
+	cacheKey := cfg.Runner + ":" + cfg.JunctiondPath
+	govVersionsMu.Lock()
+	defer govVersionsMu.Unlock()
+	if version, ok := govVersions[cacheKey]; ok {
+		return version, nil
+	}
+
+	out, err := JunctiondCommand(cfg, "tx", "gov", "submit-proposal", "--help").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("error reading submit-proposal help: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	version, err := parseGovVersion(string(out))
+	if err != nil {
+		return "", err
+	}
+	govVersions[cacheKey] = version
+	return version, nil
+}
+
+// parseGovVersion tells the two submit-proposal forms apart from their help
+// text: v1 takes the proposal file as an argument, v1beta1 builds the
+// proposal from --title/--description/--type flags.
+func parseGovVersion(help string) (string, error) {
+	usage := help
+	if i := strings.Index(help, "Flags:"); i >= 0 {
+		usage = help[:i]
+	}
+	switch {
+	case strings.Contains(usage, "submit-proposal [path/to/proposal.json]"),
+		strings.Contains(help, "submit-legacy-proposal"):
+		return GovVersionV1, nil
+	case strings.Contains(help, "--type"):
+		return GovVersionV1Beta1, nil
+	}
+	return "", fmt.Errorf("could not tell the gov module version from submit-proposal help; set gov_version to v1 or v1beta1")
+}
+
+// submitProposalArgs returns the `tx gov submit-proposal` arguments for the
+// proposal in proposalPath in the form the binary's gov module expects.
+// v1beta1 can only submit text proposals, so a proposal with messages fails.
+// Files in ProposalFormatLegacy go through legacySubmitArgs instead.
+func submitProposalArgs(cfg *ChainConfig, proposalPath string) ([]string, error) {
+	version, err := DetectGovVersion(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ProposalFormat == ProposalFormatLegacy {
+		return legacySubmitArgs(version, proposalPath)
+	}
+	if version == GovVersionV1 {
+		return []string{"tx", "gov", "submit-proposal", proposalPath}, nil
+	}
+
+	data, err := os.ReadFile(proposalPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading proposal file: %v", err)
+	}
+	var proposal struct {
+		Messages []json.RawMessage `json:"messages"`
+		Deposit  string            `json:"deposit"`
+		Title    string            `json:"title"`
+		Summary  string            `json:"summary"`
+	}
+	if err := json.Unmarshal(data, &proposal); err != nil {
+		return nil, fmt.Errorf("error parsing proposal file: %v", err)
+	}
+	if len(proposal.Messages) > 0 {
+		return nil, fmt.Errorf("junctiond's gov module is v1beta1, which cannot submit proposals with messages (%s has %d)", proposalPath, len(proposal.Messages))
+	}
+	return []string{
+		"tx", "gov", "submit-proposal",
+		"--type", "Text",
+		"--title", proposal.Title,
+		"--description", proposal.Summary,
+		"--deposit", proposal.Deposit,
+	}, nil
+}
+
+// legacySubmitArgs returns the arguments submitting the LegacyProposalFile in
+// proposalPath: `submit-legacy-proposal` on gov v1, `submit-proposal` before
+// it, with the param-change subcommand for a file with changes.
+func legacySubmitArgs(version, proposalPath string) ([]string, error) {
+	data, err := os.ReadFile(proposalPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading proposal file: %v", err)
+	}
+	var legacy LegacyProposalFile
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, fmt.Errorf("error parsing legacy proposal file: %v", err)
+	}
+
+	command := "submit-legacy-proposal"
+	if version == GovVersionV1Beta1 {
+		command = "submit-proposal"
+	}
+	if len(legacy.Changes) > 0 {
+		return []string{"tx", "gov", command, "param-change", proposalPath}, nil
+	}
+	return []string{"tx", "gov", command, "--proposal", proposalPath}, nil
+}
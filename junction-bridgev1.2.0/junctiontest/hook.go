@@ -0,0 +1,27 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// RunPostHook runs the executable at hookPath once a proposal has reached a
+// terminal status, for scenario-specific checks such as calling the bridge
+// contract. The proposal ID, status and chain ID are passed both as
+// arguments and as PROPOSAL_ID, PROPOSAL_STATUS and CHAIN_ID in the
+// environment. Its output is shown as it runs; a non-zero exit is an error.
+func RunPostHook(hookPath, chainID string, info *ProposalInfo) error {
+	cmd := exec.Command(hookPath, info.ID, info.Status, chainID)
+	cmd.Env = append(os.Environ(),
+		"PROPOSAL_ID="+info.ID,
+		"PROPOSAL_STATUS="+info.Status,
+		"CHAIN_ID="+chainID,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("post hook %s failed: %v", hookPath, err)
+	}
+	return nil
+}
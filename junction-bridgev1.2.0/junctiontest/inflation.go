@@ -0,0 +1,169 @@
+package junctiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"time"
+)
+
+// Inflation bounds set by ScenarioInflationUpdate and the window, in blocks,
+// over which it samples validator rewards before and after the change.
+const (
+	inflationTarget         = "0.250000000000000000"
+	inflationWindow         = 20
+	inflationToleranceRatio = 0.2
+)
+
+// QueryChainParams returns a module's params from its v1beta1 REST params
+// endpoint, e.g. module "mint" reads /cosmos/mint/v1beta1/params.
+func QueryChainParams(restEndpoint, module string) (map[string]interface{}, error) {
+	var response struct {
+		Params map[string]interface{} `json:"params"`
+	}
+	if err := getJSON(fmt.Sprintf("%s/cosmos/%s/v1beta1/params", restEndpoint, module), &response); err != nil {
+		return nil, fmt.Errorf("error fetching %s params: %v", module, err)
+	}
+	if response.Params == nil {
+		return nil, fmt.Errorf("no %s params in response", module)
+	}
+	return response.Params, nil
+}
+
+// CreateInflationProposal builds a proposal whose x/mint MsgUpdateParams sets
+// inflation_min and inflation_max. MsgUpdateParams replaces the whole params
+// set, so the other mint params are read from the chain and kept.
+func CreateInflationProposal(cfg *ChainConfig, newInflationMin, newInflationMax string) (*Proposal, error) {
+	params, err := QueryChainParams(cfg.RestEndpoint, "mint")
+	if err != nil {
+		return nil, err
+	}
+	params["inflation_min"] = newInflationMin
+	params["inflation_max"] = newInflationMax
+
+	message, err := json.Marshal(map[string]interface{}{
+		"@type":     "/cosmos.mint.v1beta1.MsgUpdateParams",
+		"authority": GovModuleAddress,
+		"params":    params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling mint params: %v", err)
+	}
+
+	return &Proposal{
+		RawMessages: []json.RawMessage{message},
+		Deposit:     DefaultProposalDeposit,
+		Title:       "Update inflation bounds",
+		Summary:     fmt.Sprintf("Set inflation_min to %s and inflation_max to %s", newInflationMin, newInflationMax),
+		Expedited:   cfg.Expedited,
+	}, nil
+}
+
+// QueryInflation returns the current inflation rate.
+func QueryInflation(restEndpoint string) (float64, error) {
+	var response struct {
+		Inflation string `json:"inflation"`
+	}
+	if err := getJSON(restEndpoint+"/cosmos/mint/v1beta1/inflation", &response); err != nil {
+		return 0, fmt.Errorf("error fetching inflation: %v", err)
+	}
+	return parseAmount(response.Inflation), nil
+}
+
+// ScenarioInflationUpdate passes a proposal pinning inflation to
+// inflationTarget, checks the new bounds and rate are active, and checks
+// validator rewards per block grew in proportion to the rate. Rewards are
+// sampled over idle windows before the proposal and after it passed, so tx
+// fees do not distort them.
+func ScenarioInflationUpdate(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+	valoperAddr, err := ValidatorOperatorAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return err
+	}
+
+	startHeight := int64(5)
+	if err := WaitForHeight(cfg.RPCEndpoint, startHeight+inflationWindow, inflationWindow*10*time.Second); err != nil {
+		return err
+	}
+	before, err := rewardsOverWindow(cfg, valoperAddr, startHeight)
+	if err != nil {
+		return err
+	}
+	inflationBefore, err := QueryInflation(cfg.RestEndpoint)
+	if err != nil {
+		return err
+	}
+
+	proposal, err := CreateInflationProposal(cfg, inflationTarget, inflationTarget)
+	if err != nil {
+		return err
+	}
+	proposalID, err := PassProposal(context.Background(), cfg, *proposal, filepath.Join(cfg.Home(), "inflation_proposal.json"))
+	if err != nil {
+		return err
+	}
+
+	params, err := QueryChainParams(cfg.RestEndpoint, "mint")
+	if err != nil {
+		return err
+	}
+	if parseAmount(fmt.Sprint(params["inflation_min"])) != parseAmount(inflationTarget) || parseAmount(fmt.Sprint(params["inflation_max"])) != parseAmount(inflationTarget) {
+		return fmt.Errorf("after proposal %s inflation bounds are [%v, %v], expected [%s, %s]", proposalID, params["inflation_min"], params["inflation_max"], inflationTarget, inflationTarget)
+	}
+	fmt.Printf("✅ Proposal %s set inflation bounds to [%s, %s]\n", proposalID, inflationTarget, inflationTarget)
+
+	// Clamping to the new bounds takes effect in the next block
+	height, err := LatestHeight(cfg.RPCEndpoint)
+	if err != nil {
+		return err
+	}
+	if err := WaitForHeight(cfg.RPCEndpoint, height+1+inflationWindow, inflationWindow*10*time.Second); err != nil {
+		return err
+	}
+	inflationAfter, err := QueryInflation(cfg.RestEndpoint)
+	if err != nil {
+		return err
+	}
+	if inflationAfter != parseAmount(inflationTarget) {
+		return fmt.Errorf("inflation is %g after the update, expected %s", inflationAfter, inflationTarget)
+	}
+	after, err := rewardsOverWindow(cfg, valoperAddr, height+1)
+	if err != nil {
+		return err
+	}
+
+	if before <= 0 || inflationBefore <= 0 {
+		return fmt.Errorf("no rewards or inflation before the update to compare against (rewards %.0f, inflation %g)", before, inflationBefore)
+	}
+	actualRatio := after / before
+	expectedRatio := inflationAfter / inflationBefore
+	fmt.Printf("💰 Rewards per %d blocks: %.0f%s before, %.0f%s after (x%.2f, inflation x%.2f)\n",
+		inflationWindow, before, cfg.Denom, after, cfg.Denom, actualRatio, expectedRatio)
+	if math.Abs(actualRatio-expectedRatio)/expectedRatio > inflationToleranceRatio {
+		return fmt.Errorf("rewards changed x%.2f, more than %.0f%% away from the inflation change x%.2f", actualRatio, inflationToleranceRatio*100, expectedRatio)
+	}
+	fmt.Println("✅ Validator rewards follow the new inflation rate")
+	return nil
+}
+
+// rewardsOverWindow returns how much the validator's outstanding rewards grew
+// over the inflationWindow blocks from height.
+func rewardsOverWindow(cfg *ChainConfig, valoperAddr string, height int64) (float64, error) {
+	start, err := QueryOutstandingRewards(cfg.RestEndpoint, valoperAddr, cfg.Denom, height)
+	if err != nil {
+		return 0, err
+	}
+	end, err := QueryOutstandingRewards(cfg.RestEndpoint, valoperAddr, cfg.Denom, height+inflationWindow)
+	if err != nil {
+		return 0, err
+	}
+	return end - start, nil
+}
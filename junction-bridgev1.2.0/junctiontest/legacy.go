@@ -0,0 +1,142 @@
+package junctiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+)
+
+// LegacyTextProposalType is the v1beta1 text proposal, the legacy content
+// every chain's gov router accepts.
+const LegacyTextProposalType = "/cosmos.gov.v1beta1.TextProposal"
+
+// LegacyContent is a pre-v1 gov proposal content, such as a text or
+// parameter change proposal.
+type LegacyContent struct {
+	Type        string        `json:"@type"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Changes     []ParamChange `json:"changes,omitempty"`
+}
+
+// ParamChange is one change in a /cosmos.params.v1beta1.ParameterChangeProposal.
+type ParamChange struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+// Formats for ChainConfig.ProposalFormat: ProposalFormatV1 writes a Proposal
+// with messages and metadata as is, ProposalFormatLegacy a flat
+// LegacyProposalFile for `tx gov submit-legacy-proposal`.
+const (
+	ProposalFormatV1     = "v1"
+	ProposalFormatLegacy = "legacy"
+)
+
+// LegacyProposalFile is the flat proposal file read by `tx gov
+// submit-legacy-proposal` (`submit-proposal` before gov v1): a text proposal
+// with a type, or a parameter change proposal with changes.
+type LegacyProposalFile struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Type        string        `json:"type,omitempty"`
+	Changes     []ParamChange `json:"changes,omitempty"`
+	Deposit     string        `json:"deposit"`
+}
+
+// NewLegacyProposalFile converts proposal to the legacy format. Legacy
+// proposals have no metadata field, so the metadata URI is appended to the
+// description. An evmbridge MsgUpdateParams becomes a parameter change in
+// the evmbridge params subspace; other messages cannot be expressed and
+// return an error.
+func NewLegacyProposalFile(proposal Proposal) (*LegacyProposalFile, error) {
+	if len(proposal.RawMessages) > 0 {
+		return nil, fmt.Errorf("custom proposal messages cannot be written in the legacy proposal format")
+	}
+	legacy := &LegacyProposalFile{
+		Title:       proposal.Title,
+		Description: proposal.Summary,
+		Deposit:     proposal.Deposit,
+	}
+	if proposal.Metadata != "" {
+		legacy.Description += "\n\nMetadata: " + proposal.Metadata
+	}
+
+	for _, msg := range proposal.Messages {
+		if msg.Type != "/junction.evmbridge.MsgUpdateParams" {
+			return nil, fmt.Errorf("%s messages cannot be written in the legacy proposal format", msg.Type)
+		}
+		workers, err := json.Marshal(msg.Params.BridgeWorkers)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling bridge workers: %v", err)
+		}
+		contract, _ := json.Marshal(msg.Params.BridgeContractAddress)
+		legacy.Changes = append(legacy.Changes,
+			ParamChange{Subspace: "evmbridge", Key: "BridgeWorkers", Value: string(workers)},
+			ParamChange{Subspace: "evmbridge", Key: "BridgeContractAddress", Value: string(contract)},
+		)
+	}
+	if len(legacy.Changes) == 0 {
+		legacy.Type = "Text"
+	}
+	return legacy, nil
+}
+
+// CreateLegacyProposal wraps content in a MsgExecLegacyContent, the path
+// older proposal types take through the v1 gov module.
+func CreateLegacyProposal(content LegacyContent) *Proposal {
+	message, _ := json.Marshal(struct {
+		Type      string        `json:"@type"`
+		Content   LegacyContent `json:"content"`
+		Authority string        `json:"authority"`
+	}{"/cosmos.gov.v1.MsgExecLegacyContent", content, GovModuleAddress})
+
+	return &Proposal{
+		RawMessages: []json.RawMessage{message},
+		Deposit:     DefaultProposalDeposit,
+		Title:       content.Title,
+		Summary:     content.Description,
+	}
+}
+
+// ScenarioLegacyProposalPath passes a legacy text proposal through
+// MsgExecLegacyContent and checks the v1beta1 gov API, which legacy clients
+// use, reports it with its original content.
+func ScenarioLegacyProposalPath(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	content := LegacyContent{
+		Type:        LegacyTextProposalType,
+		Title:       "Legacy content path",
+		Description: "Text proposal submitted through MsgExecLegacyContent",
+	}
+	proposal := CreateLegacyProposal(content)
+	proposal.Expedited = cfg.Expedited
+	proposalID, err := PassProposal(context.Background(), cfg, *proposal, filepath.Join(cfg.Home(), "legacy_proposal.json"))
+	if err != nil {
+		return fmt.Errorf("legacy proposal: %w", err)
+	}
+
+	var response struct {
+		Proposal struct {
+			Content LegacyContent `json:"content"`
+			Status  string        `json:"status"`
+		} `json:"proposal"`
+	}
+	if err := getJSON(fmt.Sprintf("%s/cosmos/gov/v1beta1/proposals/%s", cfg.RestEndpoint, proposalID), &response); err != nil {
+		return fmt.Errorf("error fetching proposal %s from the v1beta1 API: %v", proposalID, err)
+	}
+	got := response.Proposal.Content
+	if got.Type != content.Type || got.Title != content.Title || got.Description != content.Description {
+		return fmt.Errorf("v1beta1 API reports content %+v for proposal %s, expected %+v", got, proposalID, content)
+	}
+	fmt.Printf("✅ Legacy proposal %s passed and the v1beta1 API reports its %s content\n", proposalID, got.Type)
+	return nil
+}
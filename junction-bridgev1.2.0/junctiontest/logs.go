@@ -0,0 +1,39 @@
+package junctiontest
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// lineWriter writes complete lines to w, each with prefix, holding back a
+// partial line until its newline arrives. Whole lines keep the node's output
+// readable next to the tool's own messages, also in non-TTY CI logs.
+type lineWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func newLineWriter(w io.Writer, prefix string) *lineWriter {
+	return &lineWriter{w: w, prefix: []byte(prefix)}
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := append(append([]byte{}, l.prefix...), l.buf[:i+1]...)
+		l.buf = l.buf[i+1:]
+		if _, err := l.w.Write(line); err != nil {
+			return len(p), err
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package junctiontest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// memoryCheckpoints are the block heights at which ScenarioMemoryBaseline
+// samples the node's memory.
+var memoryCheckpoints = []int64{1, 10, 50, 100}
+
+// ProcessRSSKB returns the resident set size of pid in KB, read from
+// /proc/<pid>/status (Linux only).
+func ProcessRSSKB(pid int) (int64, error) {
+	file, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0, fmt.Errorf("error reading memory of pid %d: %v", pid, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "VmRSS:" {
+			return strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("no VmRSS for pid %d", pid)
+}
+
+// linearSlope returns the least-squares slope of ys over xs.
+func linearSlope(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// ScenarioMemoryBaseline samples junctiond's RSS at blocks 1, 10, 50 and 100
+// and fails if the fitted growth exceeds MaxMemoryGrowthKBPerBlock, catching
+// slow leaks that would not cause an OOM during a short run.
+func ScenarioMemoryBaseline(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	var heights, samples []float64
+	previous := int64(0)
+	for _, height := range memoryCheckpoints {
+		fmt.Printf("⏳ Waiting for block %d...\n", height)
+		timeout := time.Duration(height-previous)*10*time.Second + 30*time.Second
+		if err := WaitForHeight(cfg.RPCEndpoint, height, timeout); err != nil {
+			return err
+		}
+		previous = height
+
+		pid, ok := Processes.Pid("junctiond")
+		if !ok {
+			return fmt.Errorf("%w: junctiond is not running", ErrChainNotReady)
+		}
+		rss, err := ProcessRSSKB(pid)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📈 Block %d: %d KB RSS\n", height, rss)
+
+		heights = append(heights, float64(height))
+		samples = append(samples, float64(rss))
+	}
+
+	slope := linearSlope(heights, samples)
+	fmt.Printf("📊 Memory growth: %.1f KB/block (limit %.1f)\n", slope, cfg.MaxMemoryGrowthKBPerBlock)
+	if slope > cfg.MaxMemoryGrowthKBPerBlock {
+		return fmt.Errorf("memory grew %.1f KB/block, above the %.1f KB/block limit", slope, cfg.MaxMemoryGrowthKBPerBlock)
+	}
+	return nil
+}
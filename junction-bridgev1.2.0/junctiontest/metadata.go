@@ -0,0 +1,243 @@
+package junctiontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ProposalMetadata is the off-chain metadata document a proposal's metadata
+// URI points to (see draft_metadata.json).
+type ProposalMetadata struct {
+	Title             string   `json:"title"`
+	Authors           []string `json:"authors"`
+	Summary           string   `json:"summary"`
+	Details           string   `json:"details"`
+	ProposalForumURL  string   `json:"proposal_forum_url"`
+	VoteOptionContext string   `json:"vote_option_context"`
+
+	// Author attestation over the proposal (see SignProposalHash), set
+	// when SignProposal is enabled.
+	AuthorAddress   string `json:"author_address,omitempty"`
+	AuthorPubKey    string `json:"author_pub_key,omitempty"`
+	AuthorSignature string `json:"author_signature,omitempty"`
+}
+
+// ReadMetadataFile loads a metadata document such as draft_metadata.json.
+func ReadMetadataFile(path string) (*ProposalMetadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var metadata ProposalMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	return &metadata, nil
+}
+
+// WriteMetadataFile writes metadata as JSON to path, ready for IPFS upload.
+func WriteMetadataFile(path string, metadata *ProposalMetadata) error {
+	data, err := json.MarshalIndent(metadata, "", " ")
+	if err != nil {
+		return fmt.Errorf("error marshaling metadata: %v", err)
+	}
+	return WriteFileAtomic(path, data)
+}
+
+// DraftMetadataFile is the metadata template used when no MetadataTemplate
+// is configured.
+const DraftMetadataFile = "draft_metadata.json"
+
+// MetadataTemplateData is what a MetadataTemplate is rendered with.
+type MetadataTemplateData struct {
+	Config   *ChainConfig
+	Authors  []string
+	Workers  []string
+	Contract string
+	Proposal *Proposal
+}
+
+// BuildMetadata produces the metadata document for proposal: cfg's
+// MetadataTemplate rendered with a MetadataTemplateData if set, otherwise
+// draft_metadata.json. Authors and vote option context always come from cfg,
+// and the proposal's title and summary are synced to the result.
+func BuildMetadata(cfg *ChainConfig, proposal *Proposal) (*ProposalMetadata, error) {
+	var metadata *ProposalMetadata
+	var err error
+	if cfg.MetadataTemplate != "" {
+		data := MetadataTemplateData{Config: cfg, Authors: cfg.ProposalAuthorList(), Proposal: proposal}
+		if len(proposal.Messages) > 0 {
+			data.Workers = proposal.Messages[0].Params.BridgeWorkers
+			data.Contract = proposal.Messages[0].Params.BridgeContractAddress
+		}
+		metadata, err = RenderMetadataTemplate(cfg.MetadataTemplate, data)
+	} else {
+		metadata, err = ReadMetadataFile(DraftMetadataFile)
+		if err != nil {
+			err = fmt.Errorf("error reading %s (is it in the current directory?): %v", DraftMetadataFile, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata.Authors = cfg.ProposalAuthorList()
+	metadata.VoteOptionContext = cfg.VoteOptionContext
+	SyncProposalText(proposal, metadata)
+	return metadata, nil
+}
+
+// RenderMetadataTemplate executes the text/template at path with data and
+// parses the output as a metadata document. Templates can use the json
+// function to quote values, e.g. "title": {{json .Config.ChainID}}.
+func RenderMetadataTemplate(path string, data MetadataTemplateData) (*ProposalMetadata, error) {
+	tmpl, err := template.New(filepath.Base(path)).Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			out, err := json.Marshal(v)
+			return string(out), err
+		},
+		"join": strings.Join,
+	}).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metadata template %s: %v", path, err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("error rendering metadata template %s: %v", path, err)
+	}
+
+	var metadata ProposalMetadata
+	if err := json.Unmarshal(out.Bytes(), &metadata); err != nil {
+		return nil, fmt.Errorf("metadata template %s did not render valid metadata JSON: %v", path, err)
+	}
+	return &metadata, nil
+}
+
+// SyncProposalText makes proposal's title and summary match metadata's, so
+// the on-chain text and the uploaded metadata cannot disagree. The metadata
+// is the source; empty metadata fields are filled from the proposal instead.
+func SyncProposalText(proposal *Proposal, metadata *ProposalMetadata) {
+	if metadata.Title == "" {
+		metadata.Title = proposal.Title
+	}
+	if metadata.Summary == "" {
+		metadata.Summary = proposal.Summary
+	}
+	proposal.Title = metadata.Title
+	proposal.Summary = metadata.Summary
+}
+
+// FetchIPFS fetches cid from the first of gateways (e.g.
+// https://ipfs.io/ipfs/ or a local node's http://127.0.0.1:8080/ipfs/) that
+// returns it within timeout, trying them in order. It returns the content and
+// the gateway that served it, or an error listing every gateway's failure.
+func FetchIPFS(gateways []string, cid string, timeout time.Duration) ([]byte, string, error) {
+	if len(gateways) == 0 {
+		return nil, "", fmt.Errorf("no IPFS gateways configured")
+	}
+	var failures []string
+	for _, gateway := range gateways {
+		data, err := fetchURL(strings.TrimSuffix(gateway, "/")+"/"+cid, timeout)
+		if err == nil {
+			return data, gateway, nil
+		}
+		failures = append(failures, err.Error())
+	}
+	return nil, "", fmt.Errorf("could not fetch %s from any IPFS gateway: %s", cid, strings.Join(failures, "; "))
+}
+
+// fetchURL returns the body of a successful GET of url.
+func fetchURL(url string, timeout time.Duration) ([]byte, error) {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// FetchMetadata resolves an ipfs:// metadata URI through the first of
+// gateways that serves it (see FetchIPFS). Plain http(s) URIs are fetched
+// directly.
+func FetchMetadata(gateways []string, uri string, timeout time.Duration) (*ProposalMetadata, error) {
+	var body []byte
+	var err error
+	switch {
+	case strings.HasPrefix(uri, "ipfs://"):
+		body, _, err = FetchIPFS(gateways, strings.TrimPrefix(uri, "ipfs://"), timeout)
+	case strings.HasPrefix(uri, "http://"), strings.HasPrefix(uri, "https://"):
+		body, err = fetchURL(uri, timeout)
+	default:
+		return nil, fmt.Errorf("unsupported metadata URI %q", uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata ProposalMetadata
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("error parsing metadata from %s: %v", uri, err)
+	}
+	return &metadata, nil
+}
+
+// VerifyIPFSMetadata fetches cid through gateways and checks it is
+// byte-for-byte the file at metadataPath, returning the gateway that served
+// it.
+func VerifyIPFSMetadata(gateways []string, cid, metadataPath string, timeout time.Duration) (string, error) {
+	local, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %v", metadataPath, err)
+	}
+	remote, gateway, err := FetchIPFS(gateways, cid, timeout)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.Equal(local, remote) {
+		return gateway, fmt.Errorf("%s served by %s does not match %s; re-upload the file or check the CID", cid, gateway, metadataPath)
+	}
+	return gateway, nil
+}
+
+// SearchProposalsByKeyword returns the proposals whose title, summary or
+// metadata details contain keyword (case-insensitive). Metadata that cannot
+// be resolved through any of ipfsGateways is skipped, so on-chain title and
+// summary are still searched.
+func SearchProposalsByKeyword(restEndpoint string, ipfsGateways []string, ipfsTimeout time.Duration, keyword string) ([]ProposalInfo, error) {
+	proposals, err := FetchProposals(restEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error fetching proposals: %v", ErrChainNotReady, err)
+	}
+
+	keyword = strings.ToLower(keyword)
+	var matches []ProposalInfo
+	for _, proposal := range proposals.Proposals {
+		fields := []string{proposal.Title, proposal.Summary}
+		if proposal.Metadata != "" {
+			if metadata, err := FetchMetadata(ipfsGateways, proposal.Metadata, ipfsTimeout); err == nil {
+				fields = append(fields, metadata.Title, metadata.Summary, metadata.Details)
+			}
+		}
+
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), keyword) {
+				matches = append(matches, proposal)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
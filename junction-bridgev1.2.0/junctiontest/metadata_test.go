@@ -0,0 +1,79 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLocalIPFSNode starts a server standing in for both a local IPFS node,
+// serving metadata under /ipfs/<cid>, and the chain's REST API, listing
+// proposals. Unknown CIDs answer 404.
+func newLocalIPFSNode(t *testing.T, proposals []ProposalInfo, metadata map[string]ProposalMetadata) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cosmos/gov/v1/proposals", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ProposalResponse{Proposals: proposals})
+	})
+	mux.HandleFunc("/ipfs/", func(w http.ResponseWriter, r *http.Request) {
+		entry, ok := metadata[strings.TrimPrefix(r.URL.Path, "/ipfs/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(entry)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSearchProposalsByKeyword(t *testing.T) {
+	proposals := []ProposalInfo{
+		{ID: "1", Title: "Add bridge workers", Summary: "Registers two workers", Metadata: "ipfs://QmOne"},
+		{ID: "2", Title: "Community pool spend", Summary: "Funds the EVM bridge audit", Metadata: "ipfs://QmTwo"},
+		{ID: "3", Title: "Parameter change", Summary: "Tunes staking", Metadata: "ipfs://QmThree"},
+		{ID: "4", Title: "Text proposal", Summary: "Signals intent", Metadata: "ipfs://QmMissing"},
+	}
+	metadata := map[string]ProposalMetadata{
+		"QmOne":   {Title: "Add bridge workers", Details: "Adds the relayers run by the foundation"},
+		"QmTwo":   {Title: "Community pool spend", Details: "Pays for the audit"},
+		"QmThree": {Title: "Parameter change", Details: "Raises the unbonding time for the relayer set"},
+	}
+	server := newLocalIPFSNode(t, proposals, metadata)
+
+	tests := []struct {
+		name    string
+		keyword string
+		want    []string
+	}{
+		{"title", "workers", []string{"1"}},
+		{"summary", "audit", []string{"2"}},
+		{"details", "unbonding", []string{"3"}},
+		{"title and summary across proposals", "bridge", []string{"1", "2"}},
+		{"details across proposals", "relayer", []string{"1", "3"}},
+		{"case-insensitive", "BRIDGE", []string{"1", "2"}},
+		{"unresolvable metadata still searches title", "text proposal", []string{"4"}},
+		{"unresolvable metadata still searches summary", "INTENT", []string{"4"}},
+		{"no match", "slashing", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := SearchProposalsByKeyword(server.URL, []string{server.URL + "/ipfs/"}, time.Second, tt.keyword)
+			if err != nil {
+				t.Fatalf("SearchProposalsByKeyword(%q) error = %v", tt.keyword, err)
+			}
+			var got []string
+			for _, proposal := range matches {
+				got = append(got, proposal.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchProposalsByKeyword(%q) = %v, want %v", tt.keyword, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package junctiontest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// proposalStatusValues maps gov proposal statuses to the numbers exported as
+// proposal_status, following the enum order in the gov module.
+var proposalStatusValues = map[string]int{
+	"PROPOSAL_STATUS_UNSPECIFIED":    0,
+	"PROPOSAL_STATUS_DEPOSIT_PERIOD": 1,
+	"PROPOSAL_STATUS_VOTING_PERIOD":  2,
+	"PROPOSAL_STATUS_PASSED":         3,
+	"PROPOSAL_STATUS_REJECTED":       4,
+	"PROPOSAL_STATUS_FAILED":         5,
+}
+
+// RunMetrics collects the progress of a run for export in the Prometheus
+// text format.
+type RunMetrics struct {
+	mu             sync.Mutex
+	stepDurations  map[string]float64
+	phase          string
+	chainUp        bool
+	proposalStatus map[string]string
+}
+
+// Metrics is updated by the step timers, QuerySyncState and FetchProposal.
+var Metrics = &RunMetrics{stepDurations: map[string]float64{}, proposalStatus: map[string]string{}}
+
+// SetPhase records the step or phase the run is currently in.
+func (m *RunMetrics) SetPhase(phase string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.phase = phase
+}
+
+// ObserveStep records how long step took.
+func (m *RunMetrics) ObserveStep(step string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stepDurations[step] = d.Seconds()
+}
+
+// SetChainUp records whether the node last reported itself synced.
+func (m *RunMetrics) SetChainUp(up bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chainUp = up
+}
+
+// SetProposalStatus records the last status seen for proposal id.
+func (m *RunMetrics) SetProposalStatus(id, status string) {
+	if id == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.proposalStatus[id] = status
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition
+// format.
+func (m *RunMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("# HELP setup_step_duration_seconds Duration of the last run of each step.\n")
+	b.WriteString("# TYPE setup_step_duration_seconds gauge\n")
+	for _, step := range sortedKeys(m.stepDurations) {
+		fmt.Fprintf(&b, "setup_step_duration_seconds{step=%q} %g\n", step, m.stepDurations[step])
+	}
+
+	b.WriteString("# HELP current_phase The step or phase the run is in (always 1).\n")
+	b.WriteString("# TYPE current_phase gauge\n")
+	if m.phase != "" {
+		fmt.Fprintf(&b, "current_phase{phase=%q} 1\n", m.phase)
+	}
+
+	b.WriteString("# HELP chain_up Whether the node last reported itself synced.\n")
+	b.WriteString("# TYPE chain_up gauge\n")
+	up := 0
+	if m.chainUp {
+		up = 1
+	}
+	fmt.Fprintf(&b, "chain_up %d\n", up)
+
+	b.WriteString("# HELP proposal_status Last seen status per proposal (1 deposit, 2 voting, 3 passed, 4 rejected, 5 failed).\n")
+	b.WriteString("# TYPE proposal_status gauge\n")
+	for _, id := range sortedKeys(m.proposalStatus) {
+		fmt.Fprintf(&b, "proposal_status{proposal_id=%q} %d\n", id, proposalStatusValues[m.proposalStatus[id]])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,207 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// DepositAndVote deposits depositAmount on proposalID and casts voteOption
+// from cfg.KeyName in a single tx holding both MsgDeposit and MsgVote, sent
+// through the node at rpcURL. Both messages succeed or fail together. The
+// tx's gas is recorded in Gas as a deposit.
+func DepositAndVote(rpcURL string, proposalID int64, depositAmount, voteOption string, cfg *ChainConfig) error {
+	if err := ValidateVoteOption(voteOption); err != nil {
+		return err
+	}
+
+	id := strconv.FormatInt(proposalID, 10)
+	deposit, err := generateTx(cfg, rpcURL, "tx", "gov", "deposit", id, depositAmount)
+	if err != nil {
+		return err
+	}
+	vote, err := generateTx(cfg, rpcURL, "tx", "gov", "vote", id, voteOption)
+	if err != nil {
+		return err
+	}
+
+	// Append the vote's messages to the deposit tx, keeping its fee and signer
+	body := deposit["body"].(map[string]interface{})
+	messages := body["messages"].([]interface{})
+	body["messages"] = append(messages, vote["body"].(map[string]interface{})["messages"].([]interface{})...)
+
+	dir, err := os.MkdirTemp("", "deposit-and-vote")
+	if err != nil {
+		return fmt.Errorf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	unsignedPath := filepath.Join(dir, "unsigned.json")
+	signedPath := filepath.Join(dir, "signed.json")
+
+	data, err := json.Marshal(deposit)
+	if err != nil {
+		return fmt.Errorf("error marshaling combined tx: %v", err)
+	}
+	if err := os.WriteFile(unsignedPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing combined tx: %v", err)
+	}
+
+	// Sign offline with the account's current number and sequence, so
+	// signing does not depend on the node answering auth queries
+	address, err := KeyAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return fmt.Errorf("error looking up %s address: %v", cfg.KeyName, err)
+	}
+	nodeCfg := *cfg
+	nodeCfg.RPCEndpoint = rpcURL
+	accountNumber, sequence, err := FetchAccountInfo(&nodeCfg, address)
+	if err != nil {
+		return err
+	}
+	signCmd := JunctiondCommand(cfg, "tx", "sign", unsignedPath,
+		"--from", cfg.KeyName,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--offline",
+		"--account-number", strconv.FormatUint(accountNumber, 10),
+		"--sequence", strconv.FormatUint(sequence, 10),
+		"--output-document", signedPath,
+	)
+	if out, err := signCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error signing combined tx: %v: %s", err, out)
+	}
+
+	fmt.Printf("📨 Broadcasting deposit of %s and %s vote on proposal %d in one tx...\n", depositAmount, voteOption, proposalID)
+	txResponse, err := RunTxCommand(cfg, JunctiondCommand(cfg, "tx", "broadcast", signedPath, "--node", rpcURL, "--output", "json"))
+	if err != nil {
+		return err
+	}
+	_, err = Gas.WaitForTx(cfg, "deposit", txResponse.TxHash, 30*time.Second)
+	return err
+}
+
+// generateTx returns the unsigned tx built by a junctiond tx command run
+// with --generate-only. Gas is fixed at twice GasLimit so the combined tx
+// has room for both messages.
+func generateTx(cfg *ChainConfig, rpcURL string, args ...string) (map[string]interface{}, error) {
+	args = append(args,
+		"--from", cfg.KeyName,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--node", rpcURL,
+		"--gas", strconv.FormatUint(2*cfg.GasLimit, 10),
+		"--fees", TxFees(cfg, DefaultSubmitFees),
+		"--generate-only",
+	)
+	out, err := JunctiondCommand(cfg, args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("error generating %s tx: %v", args[2], err)
+	}
+
+	var tx map[string]interface{}
+	if err := json.Unmarshal(out, &tx); err != nil {
+		return nil, fmt.Errorf("error parsing generated %s tx: %v", args[2], err)
+	}
+	body, _ := tx["body"].(map[string]interface{})
+	if _, ok := body["messages"].([]interface{}); !ok {
+		return nil, fmt.Errorf("generated %s tx has no messages", args[2])
+	}
+	return tx, nil
+}
+
+// QueryVoteOption returns the option voter cast on proposalID, e.g.
+// "VOTE_OPTION_YES", or "" if it has not voted.
+func QueryVoteOption(restEndpoint, proposalID, voter string) (string, error) {
+	var response struct {
+		Vote struct {
+			Options []struct {
+				Option string `json:"option"`
+			} `json:"options"`
+		} `json:"vote"`
+	}
+	url := fmt.Sprintf("%s/cosmos/gov/v1/proposals/%s/votes/%s", restEndpoint, proposalID, voter)
+	if err := getJSON(url, &response); err != nil {
+		return "", nil
+	}
+	if len(response.Vote.Options) == 0 {
+		return "", nil
+	}
+	return response.Vote.Options[0].Option, nil
+}
+
+// ScenarioDepositAndVoteAtomic submits a proposal, then sends a combined
+// deposit-and-vote tx that must apply both messages, and a second one whose
+// deposit exceeds the proposer's balance, which must leave the first vote in
+// place.
+func ScenarioDepositAndVoteAtomic(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	txResponse, err := SubmitProposal(cfg, NewBridgeProposal("", cfg.Expedited), filepath.Join(cfg.Home(), "deposit_and_vote_proposal.json"))
+	if err != nil {
+		return err
+	}
+	result, err := WaitForTx(cfg, txResponse.TxHash, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	proposalID, err := ProposalIDFromTx(result)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseInt(proposalID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid proposal id %q: %v", proposalID, err)
+	}
+	voter, err := KeyAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return fmt.Errorf("error looking up %s address: %v", cfg.KeyName, err)
+	}
+
+	before, err := FetchProposal(cfg.RestEndpoint, proposalID)
+	if err != nil {
+		return fmt.Errorf("error fetching proposal %s: %v", proposalID, err)
+	}
+	if err := DepositAndVote(cfg.RPCEndpoint, id, "1000"+cfg.Denom, "yes", cfg); err != nil {
+		return fmt.Errorf("combined deposit and vote: %w", err)
+	}
+	after, err := FetchProposal(cfg.RestEndpoint, proposalID)
+	if err != nil {
+		return fmt.Errorf("error fetching proposal %s: %v", proposalID, err)
+	}
+	if depositOf(after, cfg.Denom)-depositOf(before, cfg.Denom) != 1000 {
+		return fmt.Errorf("total deposit went from %v to %v, expected +1000%s", before.TotalDeposit, after.TotalDeposit, cfg.Denom)
+	}
+	if option, _ := QueryVoteOption(cfg.RestEndpoint, proposalID, voter); option != "VOTE_OPTION_YES" {
+		return fmt.Errorf("vote after combined tx is %q, expected VOTE_OPTION_YES", option)
+	}
+	fmt.Println("✅ Deposit and vote were both applied")
+
+	err = DepositAndVote(cfg.RPCEndpoint, id, "1000000000000000000"+cfg.Denom, "no", cfg)
+	if !errors.Is(err, ErrTxFailed) {
+		return fmt.Errorf("combined tx with an unaffordable deposit should fail, got: %v", err)
+	}
+	if option, _ := QueryVoteOption(cfg.RestEndpoint, proposalID, voter); option != "VOTE_OPTION_YES" {
+		return fmt.Errorf("vote after failed combined tx is %q, expected the earlier VOTE_OPTION_YES", option)
+	}
+	fmt.Println("✅ Failed deposit reverted the vote in the same tx")
+	return nil
+}
+
+// depositOf returns proposal's total deposit in denom.
+func depositOf(proposal *ProposalInfo, denom string) float64 {
+	for _, coin := range proposal.TotalDeposit {
+		if coin.Denom == denom {
+			return parseAmount(coin.Amount)
+		}
+	}
+	return 0
+}
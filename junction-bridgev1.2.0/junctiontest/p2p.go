@@ -0,0 +1,38 @@
+package junctiontest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+)
+
+// SetDNSSeeds sets [p2p] seeds in config.toml to seeds, each in CometBFT's
+// node-id@host:port form where host may be a DNS name, so the node finds its
+// peers through the seeds at startup.
+func SetDNSSeeds(homeDir string, seeds []string) error {
+	for _, seed := range seeds {
+		if err := validateSeed(seed); err != nil {
+			return err
+		}
+	}
+	return setTomlValues(filepath.Join(homeDir, "config", "config.toml"), map[string]interface{}{
+		"p2p.seeds": strings.Join(seeds, ","),
+	})
+}
+
+// validateSeed checks seed is node-id@host:port with a 40 hex digit node ID.
+func validateSeed(seed string) error {
+	id, address, ok := strings.Cut(seed, "@")
+	if !ok {
+		return fmt.Errorf("invalid seed %q (expected node-id@host:port)", seed)
+	}
+	if decoded, err := hex.DecodeString(id); err != nil || len(decoded) != 20 {
+		return fmt.Errorf("invalid seed %q: node ID must be 40 hex digits", seed)
+	}
+	if host, port, err := net.SplitHostPort(address); err != nil || host == "" || port == "" {
+		return fmt.Errorf("invalid seed %q: expected host:port after @", seed)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// PIDFilePath is where the running node's process id is recorded so other
+// invocations (e.g. the proposal flow in a second terminal) can find and stop
+// it. Each chain home has its own file, so several chains can share a host.
+func PIDFilePath(cfg *ChainConfig) string {
+	return filepath.Join(cfg.Home(), "junctiond.pid")
+}
+
+func writePIDFile(cfg *ChainConfig, pid int) {
+	if err := os.WriteFile(PIDFilePath(cfg), []byte(strconv.Itoa(pid)+"\n"), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write PID file: %v\n", err)
+	}
+}
+
+func readPIDFile(cfg *ChainConfig) (int, error) {
+	data, err := os.ReadFile(PIDFilePath(cfg))
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid PID file %s: %v", PIDFilePath(cfg), err)
+	}
+	return pid, nil
+}
+
+// processAlive reports whether pid is a live junctiond process. Where /proc
+// is available the command line is checked too, so a recycled PID is not
+// mistaken for the node.
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil || process.Signal(syscall.Signal(0)) != nil {
+		return false
+	}
+	cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return true
+	}
+	return strings.Contains(string(cmdline), "junctiond")
+}
+
+// RunningChainPID returns the PID recorded for cfg's chain if that process is
+// still alive.
+func RunningChainPID(cfg *ChainConfig) (int, bool) {
+	pid, err := readPIDFile(cfg)
+	if err != nil || !processAlive(pid) {
+		return 0, false
+	}
+	return pid, true
+}
+
+// StopChainFromPIDFile interrupts the node recorded in the PID file and
+// waits up to 30s for it to exit. The PID file is removed first so RunChain
+// knows the stop was deliberate and does not restart the node. It is a no-op
+// if no PID file exists or the recorded process is gone.
+func StopChainFromPIDFile(cfg *ChainConfig) error {
+	pid, err := readPIDFile(cfg)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	os.Remove(PIDFilePath(cfg))
+
+	if !processAlive(pid) {
+		// The PID file was stale
+		return nil
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := process.Signal(syscall.SIGINT); err != nil {
+		return nil
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for processAlive(pid) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("junctiond (pid %d) did not exit within 30s", pid)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return nil
+}
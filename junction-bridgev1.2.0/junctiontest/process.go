@@ -0,0 +1,124 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// ProcessRegistry tracks background processes started by the tool so they
+// can be stopped together on exit or interrupt.
+type ProcessRegistry struct {
+	mu    sync.Mutex
+	procs map[string]*managedProcess
+}
+
+type managedProcess struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// Processes is the registry used for chains and relayers started by this package.
+var Processes = &ProcessRegistry{procs: map[string]*managedProcess{}}
+
+// Register records a started process under name. The registry owns waiting
+// on the process; use Done to observe its exit.
+func (r *ProcessRegistry) Register(name string, cmd *exec.Cmd) {
+	p := &managedProcess{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		cmd.Wait()
+		close(p.done)
+	}()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.procs[name] = p
+}
+
+// Has reports whether name is registered and has not been stopped or
+// removed.
+func (r *ProcessRegistry) Has(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.procs[name]
+	return ok
+}
+
+// Done returns a channel that is closed when the named process exits.
+func (r *ProcessRegistry) Done(name string) (<-chan struct{}, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.procs[name]
+	if !ok {
+		return nil, false
+	}
+	return p.done, true
+}
+
+// Pid returns the OS process id of the named process.
+func (r *ProcessRegistry) Pid(name string) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.procs[name]
+	if !ok || p.cmd.Process == nil {
+		return 0, false
+	}
+	return p.cmd.Process.Pid, true
+}
+
+// Remove forgets the named process without signaling it.
+func (r *ProcessRegistry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.procs, name)
+}
+
+// Stop interrupts the named process and waits up to 10s for it to exit before
+// killing it.
+func (r *ProcessRegistry) Stop(name string) error {
+	r.mu.Lock()
+	p, ok := r.procs[name]
+	delete(r.procs, name)
+	r.mu.Unlock()
+
+	if !ok || p.cmd.Process == nil {
+		return nil
+	}
+
+	select {
+	case <-p.done:
+		return nil
+	default:
+	}
+
+	if err := p.cmd.Process.Signal(os.Interrupt); err != nil {
+		return fmt.Errorf("error signaling %s: %v", name, err)
+	}
+
+	select {
+	case <-p.done:
+	case <-time.After(10 * time.Second):
+		p.cmd.Process.Kill()
+		<-p.done
+	}
+	return nil
+}
+
+// StopAll stops every registered process.
+func (r *ProcessRegistry) StopAll() {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.procs))
+	for name := range r.procs {
+		names = append(names, name)
+	}
+	r.mu.Unlock()
+
+	for _, name := range names {
+		fmt.Printf("🛑 Stopping %s...\n", name)
+		if err := r.Stop(name); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultProposalDeposit is the deposit NewBridgeProposal attaches.
+const DefaultProposalDeposit = "51000000uamf"
+
+// GovModuleAddress is the gov module account, the authority for messages
+// executed by proposals.
+const GovModuleAddress = "air10d07y265gmmuvt4z0w9aw880jnsr700jszsute"
+
+type ProposalMessage struct {
+	Type      string `json:"@type"`
+	Authority string `json:"authority"`
+	Params    struct {
+		BridgeWorkers         []string `json:"bridge_workers"`
+		BridgeContractAddress string   `json:"bridge_contract_address"`
+	} `json:"params"`
+}
+
+type Proposal struct {
+	Messages  []ProposalMessage `json:"messages"`
+	Metadata  string            `json:"metadata"`
+	Deposit   string            `json:"deposit"`
+	Title     string            `json:"title"`
+	Summary   string            `json:"summary"`
+	Expedited bool              `json:"expedited"`
+
+	// RawMessages, when set, are written verbatim in place of Messages, for
+	// proposals carrying arbitrary message types.
+	RawMessages []json.RawMessage `json:"-"`
+}
+
+// MarshalJSON writes RawMessages as the proposal's messages when set.
+func (p Proposal) MarshalJSON() ([]byte, error) {
+	type plain Proposal
+	if p.RawMessages == nil {
+		return json.Marshal(plain(p))
+	}
+	return json.Marshal(struct {
+		plain
+		Messages []json.RawMessage `json:"messages"`
+	}{plain(p), p.RawMessages})
+}
+
+// ReadProposalMessagesFile loads a JSON array of raw proposal messages,
+// checking each is an object with an @type.
+func ReadProposalMessagesFile(path string) ([]json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var messages []json.RawMessage
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("error parsing %s: expected a JSON array of messages: %v", path, err)
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("%s contains no messages", path)
+	}
+	for i, message := range messages {
+		var typed struct {
+			Type string `json:"@type"`
+		}
+		if err := json.Unmarshal(message, &typed); err != nil {
+			return nil, fmt.Errorf("%s: message %d is not a JSON object: %v", path, i, err)
+		}
+		if typed.Type == "" {
+			return nil, fmt.Errorf("%s: message %d has no @type", path, i)
+		}
+	}
+	return messages, nil
+}
+
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type ProposalInfo struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	Proposer         string `json:"proposer"`
+	Title            string `json:"title"`
+	Summary          string `json:"summary"`
+	Metadata         string `json:"metadata"`
+	Expedited        bool   `json:"expedited"`
+	FailedReason     string `json:"failed_reason"`
+	VotingStartTime  string `json:"voting_start_time"`
+	VotingEndTime    string `json:"voting_end_time"`
+	TotalDeposit     []Coin `json:"total_deposit"`
+	FinalTallyResult struct {
+		YesCount        string `json:"yes_count"`
+		AbstainCount    string `json:"abstain_count"`
+		NoCount         string `json:"no_count"`
+		NoWithVetoCount string `json:"no_with_veto_count"`
+	} `json:"final_tally_result"`
+}
+
+type ProposalResponse struct {
+	Proposals []ProposalInfo `json:"proposals"`
+}
+
+// NewBridgeProposal builds the EVM bridge parameter update proposal with the
+// given metadata URI, optionally as an expedited proposal.
+func NewBridgeProposal(metadata string, expedited bool) Proposal {
+	return Proposal{
+		Messages: []ProposalMessage{
+			{
+				Type:      "/junction.evmbridge.MsgUpdateParams",
+				Authority: GovModuleAddress,
+				Params: struct {
+					BridgeWorkers         []string `json:"bridge_workers"`
+					BridgeContractAddress string   `json:"bridge_contract_address"`
+				}{
+					BridgeWorkers:         []string{"air1h58eezgk5j4jwwpk3nxggx63gfuhnfcj78z5vj"},
+					BridgeContractAddress: "0xd47248E2f6C725Dd20C82893162aA545C345834e",
+				},
+			},
+		},
+		Metadata:  metadata,
+		Deposit:   DefaultProposalDeposit,
+		Title:     "Update EVM Bridge Authorized Unlockers",
+		Summary:   "This proposal aims to update the EVM bridge authorized unlockers list and add new bridge contract addresses to enhance the bridge's security and functionality.",
+		Expedited: expedited,
+	}
+}
+
+// FetchMinDeposit returns the chain's minimum deposit as a coin list, the
+// expedited minimum if expedited is set.
+func FetchMinDeposit(restEndpoint string, expedited bool) (string, error) {
+	var response struct {
+		Params struct {
+			MinDeposit          []Coin `json:"min_deposit"`
+			ExpeditedMinDeposit []Coin `json:"expedited_min_deposit"`
+		} `json:"params"`
+	}
+	if err := getJSON(restEndpoint+"/cosmos/gov/v1/params/deposit", &response); err != nil {
+		return "", fmt.Errorf("error fetching deposit params: %v", err)
+	}
+
+	coins := response.Params.MinDeposit
+	if expedited {
+		coins = response.Params.ExpeditedMinDeposit
+	}
+	if len(coins) == 0 {
+		return "", fmt.Errorf("chain reports no minimum deposit")
+	}
+	parts := make([]string, len(coins))
+	for i, coin := range coins {
+		parts[i] = coin.Amount + coin.Denom
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// NormalizeBridgeWorkers sorts each message's bridge workers and removes
+// duplicates, returning the removed addresses. Sorted workers keep proposal
+// files stable across runs, and the evmbridge module may reject duplicates.
+// The caller's worker slices are not modified.
+func NormalizeBridgeWorkers(proposal *Proposal) []string {
+	if proposal.Messages == nil {
+		return nil
+	}
+	var removed []string
+	messages := make([]ProposalMessage, len(proposal.Messages))
+	for i, msg := range proposal.Messages {
+		if msg.Params.BridgeWorkers != nil {
+			workers := append([]string(nil), msg.Params.BridgeWorkers...)
+			sort.Strings(workers)
+			unique := workers[:0]
+			for _, worker := range workers {
+				if len(unique) > 0 && worker == unique[len(unique)-1] {
+					removed = append(removed, worker)
+					continue
+				}
+				unique = append(unique, worker)
+			}
+			msg.Params.BridgeWorkers = unique
+		}
+		messages[i] = msg
+	}
+	proposal.Messages = messages
+	return removed
+}
+
+// WriteProposalFile writes proposal as JSON to path, with its bridge workers
+// normalized by NormalizeBridgeWorkers. Removed duplicates are reported as a
+// warning.
+func WriteProposalFile(path string, proposal Proposal) error {
+	return WriteProposalFileFormat(path, proposal, ProposalFormatV1)
+}
+
+// WriteProposalFileFormat is WriteProposalFile in the given format
+// (ProposalFormatV1 or ProposalFormatLegacy).
+func WriteProposalFileFormat(path string, proposal Proposal, format string) error {
+	if removed := NormalizeBridgeWorkers(&proposal); len(removed) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: removed duplicate bridge workers from %s: %s\n", path, strings.Join(removed, ", "))
+	}
+
+	var content interface{} = proposal
+	switch format {
+	case ProposalFormatV1, "":
+	case ProposalFormatLegacy:
+		legacy, err := NewLegacyProposalFile(proposal)
+		if err != nil {
+			return err
+		}
+		content = legacy
+	default:
+		return fmt.Errorf("invalid proposal_format %q (expected v1 or legacy)", format)
+	}
+	data, err := json.MarshalIndent(content, "", " ")
+	if err != nil {
+		return fmt.Errorf("error marshaling proposal: %v", err)
+	}
+	if err := WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
+
+// ProposalIDFromTx returns the proposal id emitted by a submit-proposal tx.
+func ProposalIDFromTx(result *TxResult) (string, error) {
+	for _, event := range result.Events {
+		if event.Type != "submit_proposal" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "proposal_id" {
+				return attr.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no proposal_id in tx %s events", result.TxHash)
+}
+
+// FetchProposals returns all proposals known to the chain's REST API.
+func FetchProposals(restEndpoint string) (*ProposalResponse, error) {
+	url := fmt.Sprintf("%s/cosmos/gov/v1/proposals?proposal_status=PROPOSAL_STATUS_UNSPECIFIED", restEndpoint)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposalResponse ProposalResponse
+	if err := json.Unmarshal(body, &proposalResponse); err != nil {
+		return nil, err
+	}
+
+	return &proposalResponse, nil
+}
+
+// FetchProposal returns a single proposal from the chain's REST API.
+func FetchProposal(restEndpoint, proposalID string) (*ProposalInfo, error) {
+	url := fmt.Sprintf("%s/cosmos/gov/v1/proposals/%s", restEndpoint, proposalID)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposalResponse struct {
+		Proposal ProposalInfo `json:"proposal"`
+	}
+	if err := json.Unmarshal(body, &proposalResponse); err != nil {
+		return nil, err
+	}
+
+	Metrics.SetProposalStatus(proposalResponse.Proposal.ID, proposalResponse.Proposal.Status)
+	return &proposalResponse.Proposal, nil
+}
+
+// WaitForProposalFinal polls the proposal until it leaves the deposit and
+// voting periods and returns its final state.
+func WaitForProposalFinal(restEndpoint, proposalID string, timeout time.Duration) (*ProposalInfo, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := checkChainAlive(); err != nil {
+			return nil, err
+		}
+
+		proposal, err := FetchProposal(restEndpoint, proposalID)
+		if err == nil && proposal.Status != "PROPOSAL_STATUS_DEPOSIT_PERIOD" && proposal.Status != "PROPOSAL_STATUS_VOTING_PERIOD" {
+			return proposal, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for proposal %s to finish", timeout, proposalID)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// ProposalSummary is the subset of a proposal shown in proposal listings.
+type ProposalSummary struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Status        string `json:"status"`
+	VotingEndTime string `json:"voting_end_time"`
+}
+
+// QueryProposals lists every proposal on chain via
+// `junctiond query gov proposals`. status, if non-empty, keeps only proposals
+// with that status (e.g. "PROPOSAL_STATUS_PASSED").
+func QueryProposals(cfg *ChainConfig, status string) ([]ProposalSummary, error) {
+	out, err := JunctiondCommand(cfg, "query", "gov", "proposals", "--output", "json").Output()
+	if err != nil {
+		// Older SDKs treat an empty proposal list as an error
+		if exitErr, ok := err.(*exec.ExitError); ok && strings.Contains(string(exitErr.Stderr), "no proposals found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying proposals: %v", err)
+	}
+
+	var response struct {
+		Proposals []ProposalSummary `json:"proposals"`
+	}
+	if err := json.Unmarshal(out, &response); err != nil {
+		return nil, fmt.Errorf("error parsing proposals: %v", err)
+	}
+
+	if status == "" {
+		return response.Proposals, nil
+	}
+	var filtered []ProposalSummary
+	for _, proposal := range response.Proposals {
+		if proposal.Status == status {
+			filtered = append(filtered, proposal)
+		}
+	}
+	return filtered, nil
+}
+
+// NormalizeProposalStatus expands short status names such as "passed" or
+// "voting_period" to the chain's PROPOSAL_STATUS_* form.
+func NormalizeProposalStatus(status string) string {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status == "" || strings.HasPrefix(status, "PROPOSAL_STATUS_") {
+		return status
+	}
+	if status == "VOTING" || status == "DEPOSIT" {
+		status += "_PERIOD"
+	}
+	return "PROPOSAL_STATUS_" + status
+}
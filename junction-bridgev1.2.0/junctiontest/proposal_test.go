@@ -0,0 +1,65 @@
+package junctiontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestProposalJSONRoundTrip checks that proposals survive being marshalled
+// to JSON and unmarshalled again, catching JSON tags that lose data.
+func TestProposalJSONRoundTrip(t *testing.T) {
+	twoWorkers := NewBridgeProposal("ipfs://bafyroundtrip", true)
+	twoWorkers.Messages[0].Params.BridgeWorkers = append(twoWorkers.Messages[0].Params.BridgeWorkers, GovModuleAddress)
+
+	noMessages := NewBridgeProposal("", false)
+	noMessages.Messages = []ProposalMessage{}
+
+	tests := []struct {
+		name     string
+		proposal Proposal
+	}{
+		{"bridge proposal", NewBridgeProposal("ipfs://QmRoundTrip", false)},
+		{"expedited with two workers", twoWorkers},
+		{"no messages or metadata", noMessages},
+		{"empty", Proposal{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.proposal)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			var decoded Proposal
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(tt.proposal, decoded) {
+				t.Errorf("proposal changed after a JSON round trip:\n  before: %+v\n  after:  %+v", tt.proposal, decoded)
+			}
+		})
+	}
+}
+
+// TestProposalRawMessages checks RawMessages, which is write-only, replaces
+// Messages verbatim on output.
+func TestProposalRawMessages(t *testing.T) {
+	raw := json.RawMessage(`{"@type":"/cosmos.gov.v1.MsgExecLegacyContent","authority":"` + GovModuleAddress + `"}`)
+	proposal := NewBridgeProposal("ipfs://bafyroundtrip", true)
+	proposal.RawMessages = []json.RawMessage{raw}
+
+	data, err := json.Marshal(proposal)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var written struct {
+		Messages []json.RawMessage `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(written.Messages) != 1 || !bytes.Equal(written.Messages[0], raw) {
+		t.Errorf("raw messages not written verbatim: %s", data)
+	}
+}
@@ -0,0 +1,124 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SetupRelayer writes a hermes config for both chains, imports the relayer
+// key, creates clients, a connection and a transfer channel, and starts the
+// relayer in the background.
+func SetupRelayer(chainA, chainB ChainConfig) error {
+	if err := CheckBinary(chainA.RelayerPath); err != nil {
+		return err
+	}
+
+	relayerHome := os.ExpandEnv(chainA.RelayerHome)
+	if err := os.MkdirAll(relayerHome, 0755); err != nil {
+		return fmt.Errorf("error creating relayer home: %v", err)
+	}
+
+	configPath := filepath.Join(relayerHome, "config.toml")
+	hermesConfig := hermesGlobalConfig + hermesChainConfig(chainA) + hermesChainConfig(chainB)
+	if err := os.WriteFile(configPath, []byte(hermesConfig), 0644); err != nil {
+		return fmt.Errorf("error writing hermes config: %v", err)
+	}
+
+	// Step 1: Import the relayer key on both chains
+	fmt.Println("\n🔑 Importing relayer keys...")
+	for _, chain := range []ChainConfig{chainA, chainB} {
+		keyCmd := exec.Command(chainA.RelayerPath, "--config", configPath,
+			"keys", "add",
+			"--chain", chain.ChainID,
+			"--mnemonic-file", os.ExpandEnv(chainA.RelayerMnemonicFile),
+			"--overwrite",
+		)
+		if err := RunCommand(keyCmd); err != nil {
+			return fmt.Errorf("error adding relayer key for %s: %v", chain.ChainID, err)
+		}
+	}
+
+	// Step 2: Create clients, connection and transfer channel
+	fmt.Println("\n🌉 Creating clients, connection and transfer channel...")
+	channelCmd := exec.Command(chainA.RelayerPath, "--config", configPath,
+		"create", "channel",
+		"--a-chain", chainA.ChainID,
+		"--b-chain", chainB.ChainID,
+		"--a-port", "transfer",
+		"--b-port", "transfer",
+		"--new-client-connection",
+		"--yes",
+	)
+	if err := RunCommand(channelCmd); err != nil {
+		return fmt.Errorf("error creating channel: %v", err)
+	}
+
+	// Step 3: Start relaying packets
+	fmt.Println("\n🚀 Starting relayer...")
+	startCmd := exec.Command(chainA.RelayerPath, "--config", configPath, "start")
+	startCmd.Stdout = os.Stdout
+	startCmd.Stderr = os.Stderr
+	if err := startCmd.Start(); err != nil {
+		return fmt.Errorf("error starting relayer: %v", err)
+	}
+	Processes.Register("relayer", startCmd)
+
+	return nil
+}
+
+const hermesGlobalConfig = `[global]
+log_level = 'info'
+
+[mode.clients]
+enabled = true
+refresh = true
+misbehaviour = false
+
+[mode.connections]
+enabled = true
+
+[mode.channels]
+enabled = true
+
+[mode.packets]
+enabled = true
+clear_interval = 100
+clear_on_start = true
+tx_confirmation = true
+
+[rest]
+enabled = false
+host = '127.0.0.1'
+port = 3000
+
+[telemetry]
+enabled = false
+host = '127.0.0.1'
+port = 3001
+`
+
+func hermesChainConfig(chain ChainConfig) string {
+	gasPrice := strings.TrimSuffix(chain.MinimumGasPrices, chain.Denom)
+	websocketURL := strings.Replace(chain.RPCEndpoint, "http", "ws", 1) + "/websocket"
+
+	return fmt.Sprintf(`
+[[chains]]
+id = '%s'
+type = 'CosmosSdk'
+rpc_addr = '%s'
+grpc_addr = '%s'
+event_source = { mode = 'push', url = '%s', batch_delay = '500ms' }
+rpc_timeout = '10s'
+account_prefix = 'air'
+key_name = 'relayer'
+store_prefix = 'ibc'
+gas_price = { price = %s, denom = '%s' }
+max_gas = 3000000
+clock_drift = '5s'
+trusting_period = '14days'
+trust_threshold = '2/3'
+`, chain.ChainID, chain.RPCEndpoint, chain.GRPCEndpoint, websocketURL, gasPrice, chain.Denom)
+}
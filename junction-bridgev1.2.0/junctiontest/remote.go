@@ -0,0 +1,23 @@
+package junctiontest
+
+import "fmt"
+
+// Modes for ChainConfig.Mode: ModeLocal runs the flow against a chain set up
+// by init-node, ModeRemote against an existing node at RPCEndpoint.
+const (
+	ModeLocal  = "local"
+	ModeRemote = "remote"
+)
+
+// CheckRemoteNode makes sure the node at cfg.RPCEndpoint answers and is on
+// cfg.ChainID, so a remote run never signs txs for the wrong network.
+func CheckRemoteNode(cfg *ChainConfig) error {
+	status, err := FetchStatus(cfg.RPCEndpoint)
+	if err != nil {
+		return fmt.Errorf("%w: cannot reach remote node at %s: %v", ErrChainNotReady, cfg.RPCEndpoint, err)
+	}
+	if network := status.Result.NodeInfo.Network; network != cfg.ChainID {
+		return fmt.Errorf("remote node at %s is on chain %q, expected chain_id %q", cfg.RPCEndpoint, network, cfg.ChainID)
+	}
+	return nil
+}
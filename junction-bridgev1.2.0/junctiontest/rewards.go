@@ -0,0 +1,130 @@
+package junctiontest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Heights and tolerance used by ScenarioStakingRewards.
+const (
+	rewardsStartHeight = 10
+	rewardsEndHeight   = 60
+	rewardsTolerance   = 0.2
+)
+
+// ValidatorOperatorAddress returns the valoper address of the key keyName.
+func ValidatorOperatorAddress(cfg *ChainConfig, keyName string) (string, error) {
+	out, err := JunctiondCommand(cfg, "keys", "show", keyName, "--bech", "val", "-a", "--keyring-backend", "os").Output()
+	if err != nil {
+		return "", fmt.Errorf("error looking up validator address of %s: %v", keyName, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// QueryOutstandingRewards returns the validator's outstanding rewards in
+// denom (commission plus delegator rewards) at height.
+func QueryOutstandingRewards(restEndpoint, valoperAddr, denom string, height int64) (float64, error) {
+	var response struct {
+		Rewards struct {
+			Rewards []Coin `json:"rewards"`
+		} `json:"rewards"`
+	}
+	url := fmt.Sprintf("%s/cosmos/distribution/v1beta1/validators/%s/outstanding_rewards", restEndpoint, valoperAddr)
+	if err := getJSONAtHeight(url, height, &response); err != nil {
+		return 0, fmt.Errorf("error fetching outstanding rewards at height %d: %v", height, err)
+	}
+	for _, coin := range response.Rewards.Rewards {
+		if coin.Denom == denom {
+			return parseAmount(coin.Amount), nil
+		}
+	}
+	return 0, nil
+}
+
+// ExpectedBlockRewards estimates the staking rewards minted per block at
+// height: annual provisions (inflation times supply, so already reflecting
+// the staking ratio) spread over blocks_per_year, minus the community tax.
+func ExpectedBlockRewards(restEndpoint string, height int64) (float64, error) {
+	var provisions struct {
+		AnnualProvisions string `json:"annual_provisions"`
+	}
+	if err := getJSONAtHeight(restEndpoint+"/cosmos/mint/v1beta1/annual_provisions", height, &provisions); err != nil {
+		return 0, fmt.Errorf("error fetching annual provisions: %v", err)
+	}
+	var mint struct {
+		Params struct {
+			BlocksPerYear string `json:"blocks_per_year"`
+		} `json:"params"`
+	}
+	if err := getJSONAtHeight(restEndpoint+"/cosmos/mint/v1beta1/params", height, &mint); err != nil {
+		return 0, fmt.Errorf("error fetching mint params: %v", err)
+	}
+	var distribution struct {
+		Params struct {
+			CommunityTax string `json:"community_tax"`
+		} `json:"params"`
+	}
+	if err := getJSONAtHeight(restEndpoint+"/cosmos/distribution/v1beta1/params", height, &distribution); err != nil {
+		return 0, fmt.Errorf("error fetching distribution params: %v", err)
+	}
+
+	blocksPerYear := parseAmount(mint.Params.BlocksPerYear)
+	if blocksPerYear == 0 {
+		return 0, fmt.Errorf("invalid blocks_per_year %q", mint.Params.BlocksPerYear)
+	}
+	perBlock := parseAmount(provisions.AnnualProvisions) / blocksPerYear
+	return perBlock * (1 - parseAmount(distribution.Params.CommunityTax)), nil
+}
+
+// ScenarioStakingRewards checks the only validator's outstanding rewards grow
+// between blocks 10 and 60 by the amount minting and distribution should
+// give it, within rewardsTolerance. Fees are not counted since the chain is
+// otherwise idle.
+func ScenarioStakingRewards(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	valoperAddr, err := ValidatorOperatorAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("⏳ Waiting for block %d...\n", rewardsEndHeight)
+	if err := WaitForHeight(cfg.RPCEndpoint, rewardsEndHeight, rewardsEndHeight*10*time.Second); err != nil {
+		return err
+	}
+
+	start, err := QueryOutstandingRewards(cfg.RestEndpoint, valoperAddr, cfg.Denom, rewardsStartHeight)
+	if err != nil {
+		return err
+	}
+	end, err := QueryOutstandingRewards(cfg.RestEndpoint, valoperAddr, cfg.Denom, rewardsEndHeight)
+	if err != nil {
+		return err
+	}
+	perBlock, err := ExpectedBlockRewards(cfg.RestEndpoint, rewardsStartHeight)
+	if err != nil {
+		return err
+	}
+
+	actual := end - start
+	expected := perBlock * (rewardsEndHeight - rewardsStartHeight)
+	fmt.Printf("💰 Rewards at block %d: %.0f%s, at block %d: %.0f%s\n", rewardsStartHeight, start, cfg.Denom, rewardsEndHeight, end, cfg.Denom)
+	fmt.Printf("   Increase %.0f%s, expected about %.0f%s\n", actual, cfg.Denom, expected, cfg.Denom)
+
+	if actual <= 0 {
+		return fmt.Errorf("validator rewards did not increase between blocks %d and %d", rewardsStartHeight, rewardsEndHeight)
+	}
+	if expected > 0 && math.Abs(actual-expected)/expected > rewardsTolerance {
+		return fmt.Errorf("rewards increased by %.0f%s, more than %.0f%% away from the expected %.0f%s",
+			actual, cfg.Denom, rewardsTolerance*100, expected, cfg.Denom)
+	}
+	fmt.Println("✅ Staking rewards are being distributed at the expected rate")
+	return nil
+}
@@ -0,0 +1,112 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Runners select how junctiond is executed.
+const (
+	RunnerLocal  = "local"
+	RunnerDocker = "docker"
+)
+
+// JunctiondCommand returns the command that runs junctiond with args: the
+// local binary at JunctiondPath, or with Runner "docker", junctiond inside
+// DockerImage. In docker mode the chain home is mounted at the container's
+// default home (/root/.junction) and, like the working, temp and output
+// directories, at its host path, so relative files and explicit --home paths
+// resolve the same inside and outside the container. With cfg.Verbose set,
+// the full command line is echoed so it can be copied and re-run.
+func JunctiondCommand(cfg *ChainConfig, args ...string) *exec.Cmd {
+	cmd := junctiondCommand(cfg, withNode(cfg, args)...)
+	if cfg.Verbose {
+		fmt.Printf("$ %s\n", shellJoin(cmd.Args))
+	}
+	return cmd
+}
+
+func junctiondCommand(cfg *ChainConfig, args ...string) *exec.Cmd {
+	if cfg.Runner != RunnerDocker {
+		return exec.Command(cfg.JunctiondPath, args...)
+	}
+
+	home := cfg.Home()
+	dockerArgs := []string{"run", "--rm", "-i", "--init", "--network", "host",
+		"-v", home + ":/root/.junction",
+	}
+	mounted := map[string]bool{"/root/.junction": true}
+	cwd, _ := os.Getwd()
+	outputDir, _ := filepath.Abs(cfg.OutputDir)
+	for _, dir := range []string{home, os.TempDir(), cwd, outputDir} {
+		if dir == "" || mounted[dir] {
+			continue
+		}
+		mounted[dir] = true
+		dockerArgs = append(dockerArgs, "-v", dir+":"+dir)
+	}
+	if cwd != "" {
+		dockerArgs = append(dockerArgs, "-w", cwd)
+	}
+	dockerArgs = append(dockerArgs, cfg.DockerImage, "junctiond")
+
+	return exec.Command("docker", append(dockerArgs, args...)...)
+}
+
+// shellJoin joins args into a command line a POSIX shell reads back as the
+// same arguments.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=,@+%") == "" {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// CheckJunctiond verifies junctiond can be run with the configured runner.
+func CheckJunctiond(cfg *ChainConfig) error {
+	switch cfg.Runner {
+	case RunnerLocal, "":
+		if err := CheckBinary(cfg.JunctiondPath); err != nil {
+			return err
+		}
+		return VerifyJunctiondChecksum(cfg)
+	case RunnerDocker:
+		if cfg.DockerImage == "" {
+			return fmt.Errorf("%w: runner is docker but docker_image is not set", ErrMissingDependency)
+		}
+		if cfg.JunctiondSHA256 != "" {
+			return fmt.Errorf("junctiond_sha256 only applies to the local runner; pin docker_image by digest (image@sha256:...) instead")
+		}
+		return CheckBinary("docker")
+	default:
+		return fmt.Errorf("invalid runner %q (expected local or docker)", cfg.Runner)
+	}
+}
+
+// withNode adds --node RPCEndpoint to query and tx commands that do not name
+// a node themselves, so they reach the configured node rather than
+// junctiond's default of localhost.
+func withNode(cfg *ChainConfig, args []string) []string {
+	if len(args) == 0 || cfg.RPCEndpoint == "" {
+		return args
+	}
+	switch args[0] {
+	case "query", "q", "tx":
+	default:
+		return args
+	}
+	for _, arg := range args {
+		if arg == "--node" || strings.HasPrefix(arg, "--node=") {
+			return args
+		}
+	}
+	return append(append([]string{}, args...), "--node", cfg.RPCEndpoint)
+}
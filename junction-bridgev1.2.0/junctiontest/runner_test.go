@@ -0,0 +1,105 @@
+package junctiontest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJunctiondCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        ChainConfig
+		args       []string
+		wantPrefix []string
+		wantSuffix []string
+	}{
+		{
+			name:       "local runner",
+			cfg:        ChainConfig{Runner: RunnerLocal, JunctiondPath: "./build/junctiond"},
+			args:       []string{"keys", "list"},
+			wantPrefix: []string{"./build/junctiond", "keys", "list"},
+		},
+		{
+			name:       "empty runner is local",
+			cfg:        ChainConfig{JunctiondPath: "/usr/local/bin/junctiond"},
+			args:       []string{"version"},
+			wantPrefix: []string{"/usr/local/bin/junctiond", "version"},
+		},
+		{
+			name:       "docker runner",
+			cfg:        ChainConfig{Runner: RunnerDocker, DockerImage: "junction:test", HomeDir: "/tmp/junction-home"},
+			args:       []string{"status"},
+			wantPrefix: []string{"docker", "run", "--rm", "-i", "--init", "--network", "host", "-v", "/tmp/junction-home:/root/.junction"},
+			wantSuffix: []string{"junction:test", "junctiond", "status"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := JunctiondCommand(&tt.cfg, tt.args...)
+			if len(cmd.Args) < len(tt.wantPrefix) || !reflect.DeepEqual(cmd.Args[:len(tt.wantPrefix)], tt.wantPrefix) {
+				t.Errorf("args = %q, want prefix %q", cmd.Args, tt.wantPrefix)
+			}
+			if n := len(tt.wantSuffix); n > 0 && (len(cmd.Args) < n || !reflect.DeepEqual(cmd.Args[len(cmd.Args)-n:], tt.wantSuffix)) {
+				t.Errorf("args = %q, want suffix %q", cmd.Args, tt.wantSuffix)
+			}
+		})
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"plain words", []string{"junctiond", "query", "gov", "proposal", "1"}, "junctiond query gov proposal 1"},
+		{"safe punctuation", []string{"--node=tcp://localhost:26657", "--fees", "500uamf,10utest", "a@b+c%d"}, "--node=tcp://localhost:26657 --fees 500uamf,10utest a@b+c%d"},
+		{"empty argument", []string{"echo", ""}, "echo ''"},
+		{"spaces", []string{"--title", "Update bridge workers"}, "--title 'Update bridge workers'"},
+		{"single quote", []string{"it's"}, `'it'\''s'`},
+		{"shell metacharacters", []string{"$HOME", "a;b", "*"}, "'$HOME' 'a;b' '*'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shellJoin(tt.args); got != tt.want {
+				t.Errorf("shellJoin(%q) = %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNode(t *testing.T) {
+	withEndpoint := ChainConfig{RPCEndpoint: "http://localhost:26657"}
+	tests := []struct {
+		name string
+		cfg  ChainConfig
+		args []string
+		want []string
+	}{
+		{"query", withEndpoint, []string{"query", "bank", "balances", "air1x"}, []string{"query", "bank", "balances", "air1x", "--node", "http://localhost:26657"}},
+		{"query alias", withEndpoint, []string{"q", "gov", "params"}, []string{"q", "gov", "params", "--node", "http://localhost:26657"}},
+		{"tx", withEndpoint, []string{"tx", "gov", "vote", "1", "yes"}, []string{"tx", "gov", "vote", "1", "yes", "--node", "http://localhost:26657"}},
+		{"explicit node", withEndpoint, []string{"query", "tx", "ABC", "--node", "tcp://other:26657"}, []string{"query", "tx", "ABC", "--node", "tcp://other:26657"}},
+		{"explicit node with equals", withEndpoint, []string{"tx", "broadcast", "tx.json", "--node=tcp://other:26657"}, []string{"tx", "broadcast", "tx.json", "--node=tcp://other:26657"}},
+		{"other command", withEndpoint, []string{"keys", "show", "test1"}, []string{"keys", "show", "test1"}},
+		{"no endpoint", ChainConfig{}, []string{"query", "gov", "params"}, []string{"query", "gov", "params"}},
+		{"no args", withEndpoint, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withNode(&tt.cfg, tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withNode(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNodeKeepsCallerArgs(t *testing.T) {
+	cfg := ChainConfig{RPCEndpoint: "http://localhost:26657"}
+	args := make([]string, 2, 8)
+	copy(args, []string{"query", "gov"})
+	withNode(&cfg, args)
+	if extended := args[:4]; extended[2] != "" || extended[3] != "" {
+		t.Errorf("withNode wrote into the caller's backing array: %q", extended)
+	}
+}
@@ -0,0 +1,41 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"path"
+)
+
+// DefaultTestChainIDs are the chain IDs treated as test chains when
+// TestChainIDs is not configured: the local default and anything named as a
+// test, dev or local network.
+var DefaultTestChainIDs = []string{"junction", "*test*", "*devnet*", "*local*"}
+
+// IsTestChain reports whether chainID matches one of patterns, which are
+// exact chain IDs or path.Match globs such as "*devnet*".
+func IsTestChain(chainID string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, chainID); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTestChain refuses to go on against a chain ID outside
+// TestChainIDs, since submitting a proposal on a production chain would be
+// a real governance action. AllowNonTestChain overrides the refusal with a
+// prominent warning instead.
+func CheckTestChain(cfg *ChainConfig) error {
+	if IsTestChain(cfg.ChainID, cfg.TestChainIDs) {
+		return nil
+	}
+	if !cfg.AllowNonTestChain {
+		return fmt.Errorf("chain_id %q is not in test_chain_ids %v; refusing to submit to what may be a production chain (set ALLOW_NON_TEST_CHAIN=true if this really is a test chain)", cfg.ChainID, cfg.TestChainIDs)
+	}
+	fmt.Fprintln(os.Stderr, "⚠️  ============================================================")
+	fmt.Fprintf(os.Stderr, "⚠️  WARNING: chain_id %q is not a known test chain.\n", cfg.ChainID)
+	fmt.Fprintln(os.Stderr, "⚠️  ALLOW_NON_TEST_CHAIN is set, so proposals WILL be submitted.")
+	fmt.Fprintln(os.Stderr, "⚠️  ============================================================")
+	return nil
+}
@@ -0,0 +1,236 @@
+package junctiontest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Scenario is an end-to-end chain test. Scenarios set up and start their own
+// chain, so no node may be running when one is executed.
+type Scenario struct {
+	Name        string
+	Description string
+	Run         func(cfg *ChainConfig) error
+}
+
+var scenarios = map[string]Scenario{}
+
+// RegisterScenario makes s available to LookupScenario and ScenarioNames.
+func RegisterScenario(s Scenario) {
+	scenarios[s.Name] = s
+}
+
+// LookupScenario returns the scenario registered under name.
+func LookupScenario(name string) (Scenario, bool) {
+	s, ok := scenarios[name]
+	return s, ok
+}
+
+// ScenarioNames returns the registered scenario names in sorted order.
+func ScenarioNames() []string {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterScenario(Scenario{
+		Name:        "custom-deposit-denom",
+		Description: "Submit a proposal whose deposit is in a secondary genesis denomination",
+		Run:         ScenarioCustomDepositDenom,
+	})
+	RegisterScenario(Scenario{
+		Name:        "api-cors",
+		Description: "Enable CORS for CORS_ORIGINS and check the REST API and RPC send CORS headers",
+		Run:         ScenarioAPICORS,
+	})
+	RegisterScenario(Scenario{
+		Name:        "governance-under-unbonding",
+		Description: "Unbond one of three validators, then check a proposal's quorum is measured against the reduced bonded power",
+		Run:         ScenarioGovernanceUnderUnbonding,
+	})
+	RegisterScenario(Scenario{
+		Name:        "deposit-refund-policy",
+		Description: "Check expired and quorum-failed proposal deposits are burned or refunded as the gov burn params say",
+		Run:         ScenarioDepositRefundPolicy,
+	})
+	RegisterScenario(Scenario{
+		Name:        "single-depositor",
+		Description: "Fund one account with exactly the minimum deposit plus fees and check its proposal goes straight to voting",
+		Run:         ScenarioSingleDepositor,
+	})
+	RegisterScenario(Scenario{
+		Name:        "staking-rewards",
+		Description: "Check validator rewards between blocks 10 and 60 match inflation and community tax",
+		Run:         ScenarioStakingRewards,
+	})
+	RegisterScenario(Scenario{
+		Name:        "inflation-update",
+		Description: "Pass a mint params proposal pinning inflation and check the bounds, rate and validator rewards follow",
+		Run:         ScenarioInflationUpdate,
+	})
+	RegisterScenario(Scenario{
+		Name:        "memory-baseline",
+		Description: "Check junctiond memory does not grow steadily over the first 100 blocks",
+		Run:         ScenarioMemoryBaseline,
+	})
+	RegisterScenario(Scenario{
+		Name:        "bridge-worker-rotation",
+		Description: "Pass two worker-set proposals and check only the latest set is active",
+		Run:         ScenarioBridgeWorkerRotation,
+	})
+	RegisterScenario(Scenario{
+		Name:        "deposit-and-vote",
+		Description: "Deposit and vote in one tx and check a failing deposit also reverts the vote",
+		Run:         ScenarioDepositAndVoteAtomic,
+	})
+	RegisterScenario(Scenario{
+		Name:        "legacy-proposal-path",
+		Description: "Pass a legacy text proposal via MsgExecLegacyContent and check the v1beta1 API reports it",
+		Run:         ScenarioLegacyProposalPath,
+	})
+	RegisterScenario(Scenario{
+		Name:        "endurance",
+		Description: "Pass proposals back to back for endurance_duration and report the failure rate",
+		Run:         ScenarioEndurance,
+	})
+}
+
+// ScenarioCustomDepositDenom funds the proposer with a secondary denomination
+// at genesis and submits a proposal whose deposit uses it. The scenario
+// passes if the chain either accepts the deposit or rejects it with a
+// denomination error; any other outcome is a failure.
+func ScenarioCustomDepositDenom(cfg *ChainConfig) error {
+	const customDenom = "utest"
+
+	scenarioCfg := *cfg
+	scenarioCfg.Amount = cfg.Amount + ",1000000000000" + customDenom
+
+	if err := SetupChain(&scenarioCfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(&scenarioCfg); err != nil {
+		return err
+	}
+
+	proposal := NewBridgeProposal("", scenarioCfg.Expedited)
+	proposal.Deposit = "51000000" + customDenom
+	proposalPath := filepath.Join(scenarioCfg.Home(), "custom_deposit_proposal.json")
+
+	txResponse, err := SubmitProposal(&scenarioCfg, proposal, proposalPath)
+	if errors.Is(err, ErrTxFailed) {
+		return checkDenomRejection(customDenom, txResponse.RawLog)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := WaitForTx(&scenarioCfg, txResponse.TxHash, 30*time.Second)
+	if errors.Is(err, ErrTxFailed) {
+		return checkDenomRejection(customDenom, result.RawLog)
+	}
+	if err != nil {
+		return err
+	}
+
+	proposalID, err := ProposalIDFromTx(result)
+	if err != nil {
+		return err
+	}
+	info, err := FetchProposal(scenarioCfg.RestEndpoint, proposalID)
+	if err != nil {
+		return fmt.Errorf("error fetching proposal %s: %v", proposalID, err)
+	}
+
+	for _, coin := range info.TotalDeposit {
+		if coin.Denom == customDenom {
+			fmt.Printf("✅ Chain accepted a %s deposit on proposal %s: %s%s\n", customDenom, proposalID, coin.Amount, coin.Denom)
+			return nil
+		}
+	}
+	return fmt.Errorf("proposal %s was accepted but its total deposit %v has no %s", proposalID, info.TotalDeposit, customDenom)
+}
+
+// ScenarioSingleDepositor funds a fresh account with exactly the chain's
+// minimum deposit plus submit fees, submits a proposal carrying the whole
+// deposit from it in one tx and checks the proposal skips the deposit period.
+func ScenarioSingleDepositor(cfg *ChainConfig) error {
+	const depositorKey = "single-depositor"
+
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	minDeposit, err := FetchMinDeposit(cfg.RestEndpoint, cfg.Expedited)
+	if err != nil {
+		return err
+	}
+	funding, err := ParseCoins(minDeposit + "," + TxFees(cfg, DefaultSubmitFees))
+	if err != nil {
+		return err
+	}
+
+	if err := EnsureKey(cfg, depositorKey); err != nil {
+		return err
+	}
+	depositorAddr, err := KeyAddress(cfg, depositorKey)
+	if err != nil {
+		return fmt.Errorf("error looking up %s address: %v", depositorKey, err)
+	}
+
+	fmt.Printf("💰 Funding %s with %s\n", depositorAddr, FormatCoins(funding))
+	sendResponse, err := BankSend(cfg, depositorAddr, FormatCoins(funding))
+	if err != nil {
+		return err
+	}
+	if _, err := WaitForTx(cfg, sendResponse.TxHash, 30*time.Second); err != nil {
+		return err
+	}
+
+	depositorCfg := *cfg
+	depositorCfg.KeyName = depositorKey
+	proposal := NewBridgeProposal("", cfg.Expedited)
+	proposal.Deposit = minDeposit
+	txResponse, err := SubmitProposal(&depositorCfg, proposal, filepath.Join(cfg.Home(), "single_depositor_proposal.json"))
+	if err != nil {
+		return err
+	}
+	result, err := WaitForTx(cfg, txResponse.TxHash, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	proposalID, err := ProposalIDFromTx(result)
+	if err != nil {
+		return err
+	}
+
+	info, err := FetchProposal(cfg.RestEndpoint, proposalID)
+	if err != nil {
+		return fmt.Errorf("error fetching proposal %s: %v", proposalID, err)
+	}
+	if info.Status != "PROPOSAL_STATUS_VOTING_PERIOD" {
+		return fmt.Errorf("proposal %s with the full %s deposit is in %s, expected the voting period", proposalID, minDeposit, info.Status)
+	}
+	fmt.Printf("✅ Proposal %s entered the voting period directly with a single %s deposit\n", proposalID, minDeposit)
+	return nil
+}
+
+// checkDenomRejection treats a rejection that mentions the deposit
+// denomination as the expected behavior of chains restricting deposit denoms.
+func checkDenomRejection(denom, rawLog string) error {
+	if strings.Contains(rawLog, denom) || strings.Contains(strings.ToLower(rawLog), "denom") {
+		fmt.Printf("✅ Chain rejected the %s deposit with a denomination error: %s\n", denom, rawLog)
+		return nil
+	}
+	return fmt.Errorf("proposal rejected for an unexpected reason: %s", rawLog)
+}
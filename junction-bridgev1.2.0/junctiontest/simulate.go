@@ -0,0 +1,47 @@
+package junctiontest
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var gasEstimatePattern = regexp.MustCompile(`gas estimate: (\d+)`)
+
+// SimulateProposalFile dry-runs submitting the proposal in proposalPath
+// (`tx gov submit-proposal --dry-run`) and returns the estimated gas. The
+// node runs the tx through the ante handler and the message handlers in
+// simulation mode, so a malformed message or unaffordable deposit fails here
+// without broadcasting anything. Failures wrap ErrTxFailed like a rejected
+// tx would.
+func SimulateProposalFile(cfg *ChainConfig, proposalPath string) (uint64, error) {
+	simulateArgs, err := submitProposalArgs(cfg, proposalPath)
+	if err != nil {
+		return 0, err
+	}
+	simulateArgs = append(simulateArgs,
+		"--from", cfg.KeyName,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--gas", "auto",
+		"--gas-adjustment", strconv.FormatFloat(cfg.GasAdjustment, 'f', -1, 64),
+		"--fees", TxFees(cfg, DefaultSubmitFees),
+		"--dry-run",
+	)
+
+	var output bytes.Buffer
+	cmd := JunctiondCommand(cfg, simulateArgs...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return 0, txFailure("(simulated)", "", 0, strings.TrimSpace(output.String()))
+	}
+
+	match := gasEstimatePattern.FindStringSubmatch(output.String())
+	if match == nil {
+		return 0, fmt.Errorf("no gas estimate in simulation output: %s", strings.TrimSpace(output.String()))
+	}
+	return strconv.ParseUint(match[1], 10, 64)
+}
@@ -0,0 +1,135 @@
+package junctiontest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlashEvent is one slashing of a validator.
+type SlashEvent struct {
+	Height      int64
+	Reason      string
+	SlashFactor string
+	JailedUntil time.Time
+}
+
+// ValidatorConsAddress returns the node's validator consensus address
+// (airvalcons...), the address slash events refer to.
+func ValidatorConsAddress(cfg *ChainConfig) (string, error) {
+	out, err := JunctiondCommand(cfg, "comet", "show-address", "--home", cfg.Home()).Output()
+	if err != nil {
+		return "", fmt.Errorf("error reading validator consensus address: %v", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+type slashingParams struct {
+	SlashFractionDoubleSign string `json:"slash_fraction_double_sign"`
+	SlashFractionDowntime   string `json:"slash_fraction_downtime"`
+	DowntimeJailDuration    string `json:"downtime_jail_duration"`
+}
+
+// QuerySlashingHistory returns every slash of the validator with consensus
+// address validatorAddr, found through the node's indexed block events.
+// SlashFactor and JailedUntil are derived from the current slashing params;
+// double-sign slashes tombstone the validator, so JailedUntil is the far
+// future for those.
+func QuerySlashingHistory(cfg *ChainConfig, validatorAddr string) ([]SlashEvent, error) {
+	var params struct {
+		Params slashingParams `json:"params"`
+	}
+	if err := getJSON(cfg.RestEndpoint+"/cosmos/slashing/v1beta1/params", &params); err != nil {
+		return nil, fmt.Errorf("error fetching slashing params: %v", err)
+	}
+	jailDuration, err := time.ParseDuration(params.Params.DowntimeJailDuration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid downtime_jail_duration %q: %v", params.Params.DowntimeJailDuration, err)
+	}
+
+	query := url.QueryEscape(fmt.Sprintf(`"slash.address='%s'"`, validatorAddr))
+	var search struct {
+		Result struct {
+			Blocks []struct {
+				Block struct {
+					Header struct {
+						Height string    `json:"height"`
+						Time   time.Time `json:"time"`
+					} `json:"header"`
+				} `json:"block"`
+			} `json:"blocks"`
+		} `json:"result"`
+	}
+	if err := getJSON(fmt.Sprintf("%s/block_search?query=%s&per_page=100&order_by=%%22asc%%22", cfg.RPCEndpoint, query), &search); err != nil {
+		return nil, fmt.Errorf("error searching slash events: %v", err)
+	}
+
+	var history []SlashEvent
+	for _, block := range search.Result.Blocks {
+		height, err := strconv.ParseInt(block.Block.Header.Height, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block height %q", block.Block.Header.Height)
+		}
+		reasons, err := blockSlashReasons(cfg.RPCEndpoint, height, validatorAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reason := range reasons {
+			event := SlashEvent{Height: height, Reason: reason}
+			if reason == "double_sign" {
+				event.SlashFactor = params.Params.SlashFractionDoubleSign
+				event.JailedUntil = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+			} else {
+				event.SlashFactor = params.Params.SlashFractionDowntime
+				event.JailedUntil = block.Block.Header.Time.Add(jailDuration)
+			}
+			history = append(history, event)
+		}
+	}
+	return history, nil
+}
+
+// blockSlashReasons returns the reason of each slash event for address in
+// the block results at height.
+func blockSlashReasons(rpcURL string, height int64, address string) ([]string, error) {
+	type event struct {
+		Type       string `json:"type"`
+		Attributes []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"attributes"`
+	}
+	var results struct {
+		Result struct {
+			FinalizeBlockEvents []event `json:"finalize_block_events"`
+			BeginBlockEvents    []event `json:"begin_block_events"`
+		} `json:"result"`
+	}
+	if err := getJSON(fmt.Sprintf("%s/block_results?height=%d", rpcURL, height), &results); err != nil {
+		return nil, fmt.Errorf("error fetching block %d results: %v", height, err)
+	}
+
+	var reasons []string
+	events := append(results.Result.FinalizeBlockEvents, results.Result.BeginBlockEvents...)
+	for _, e := range events {
+		if e.Type != "slash" {
+			continue
+		}
+		var eventAddress, reason string
+		for _, attr := range e.Attributes {
+			switch attr.Key {
+			case "address":
+				eventAddress = attr.Value
+			case "reason":
+				reason = attr.Value
+			}
+		}
+		if eventAddress == address {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons, nil
+}
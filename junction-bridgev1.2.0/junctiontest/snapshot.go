@@ -0,0 +1,178 @@
+package junctiontest
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SnapshotPath returns the archive path for the named snapshot.
+func SnapshotPath(cfg *ChainConfig, name string) string {
+	return filepath.Join(os.ExpandEnv(cfg.SnapshotDir), name+".tar.gz")
+}
+
+// ensureChainStopped refuses to touch the data directory while the chain's
+// recorded process is alive or a node is answering on the RPC endpoint.
+func ensureChainStopped(cfg *ChainConfig) error {
+	if pid, ok := RunningChainPID(cfg); ok {
+		return fmt.Errorf("junctiond (pid %d) is running from %s; stop it before taking or restoring a snapshot", pid, cfg.Home())
+	}
+	if _, err := FetchStatus(cfg.RPCEndpoint); err == nil {
+		return fmt.Errorf("chain is running at %s; stop junctiond before taking or restoring a snapshot", cfg.RPCEndpoint)
+	}
+	return nil
+}
+
+func validateSnapshotName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid snapshot name %q", name)
+	}
+	return nil
+}
+
+// CreateSnapshot archives the chain home directory as the named snapshot,
+// replacing any existing snapshot with that name.
+func CreateSnapshot(cfg *ChainConfig, name string) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+	if err := ensureChainStopped(cfg); err != nil {
+		return err
+	}
+
+	homeDir := cfg.Home()
+	if _, err := os.Stat(homeDir); err != nil {
+		return fmt.Errorf("error reading chain home: %v", err)
+	}
+
+	path := SnapshotPath(cfg, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating snapshot directory: %v", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot: %v", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.Walk(homeDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(homeDir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("error archiving %s: %v", homeDir, err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot: %v", err)
+	}
+	return nil
+}
+
+// RestoreSnapshot replaces the chain home directory with the contents of the
+// named snapshot.
+func RestoreSnapshot(cfg *ChainConfig, name string) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+	if err := ensureChainStopped(cfg); err != nil {
+		return err
+	}
+
+	file, err := os.Open(SnapshotPath(cfg, name))
+	if err != nil {
+		return fmt.Errorf("error opening snapshot %q: %v", name, err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("error reading snapshot %q: %v", name, err)
+	}
+	defer gz.Close()
+
+	homeDir := cfg.Home()
+	if err := os.RemoveAll(homeDir); err != nil {
+		return fmt.Errorf("error removing chain home: %v", err)
+	}
+	if err := os.MkdirAll(homeDir, 0755); err != nil {
+		return fmt.Errorf("error creating chain home: %v", err)
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading snapshot %q: %v", name, err)
+		}
+
+		target := filepath.Join(homeDir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(homeDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("snapshot %q contains invalid path %q", name, header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(header.Mode)); err != nil {
+				return fmt.Errorf("error restoring %s: %v", header.Name, err)
+			}
+		case tar.TypeReg:
+			if err := restoreFile(tr, target, os.FileMode(header.Mode)); err != nil {
+				return fmt.Errorf("error restoring %s: %v", header.Name, err)
+			}
+		}
+	}
+}
+
+func restoreFile(r io.Reader, target string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	_, err = io.Copy(dst, r)
+	return err
+}
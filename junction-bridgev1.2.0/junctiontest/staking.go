@@ -0,0 +1,229 @@
+package junctiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultStakingFees is the fee used for staking txs.
+const DefaultStakingFees = "100uamf"
+
+// Stakes used by ScenarioGovernanceUnderUnbonding, chosen so the voter's
+// delegation reaches quorum against the bonded total after validator 3
+// unbonds but not before. Validators 2 and 3 together stay under a third of
+// the voting power so the chain keeps producing blocks without their nodes.
+const (
+	unbondingVal2Stake   = 500000000
+	unbondingVal3Stake   = 3000000000
+	unbondingVoterStake  = 6000000000
+	unbondingFundingFees = 10000000
+)
+
+// runStakingTx broadcasts a staking tx from keyName and waits for it to be
+// included.
+func runStakingTx(cfg *ChainConfig, keyName string, args ...string) error {
+	gasArgs, err := TxGasFlags(cfg, DefaultStakingFees)
+	if err != nil {
+		return err
+	}
+	txArgs := append(append([]string{"tx", "staking"}, args...),
+		"--from", keyName,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--output", "json",
+		"-y",
+	)
+	txResponse, err := RunTxCommand(cfg, JunctiondCommand(cfg, append(txArgs, gasArgs...)...))
+	if err != nil {
+		return err
+	}
+	_, err = WaitForTx(cfg, txResponse.TxHash, 30*time.Second)
+	return err
+}
+
+// CreateValidator registers keyName as a validator self-bonding amount. Its
+// consensus key is generated in a scratch home, so no node signs for it: a
+// validator created this way only adds bonded stake, and stays live only
+// while validators without nodes hold under a third of the voting power.
+func CreateValidator(cfg *ChainConfig, keyName, amount string) error {
+	scratchHome, err := os.MkdirTemp("", "junction-validator-")
+	if err != nil {
+		return fmt.Errorf("error creating scratch home: %v", err)
+	}
+	defer os.RemoveAll(scratchHome)
+
+	if out, err := JunctiondCommand(cfg, "init", keyName, "--chain-id", cfg.ChainID, "--home", scratchHome).CombinedOutput(); err != nil {
+		return fmt.Errorf("error generating consensus key for %s: %v: %s", keyName, err, out)
+	}
+	pubKey, err := JunctiondCommand(cfg, "comet", "show-validator", "--home", scratchHome).Output()
+	if err != nil {
+		return fmt.Errorf("error reading consensus key for %s: %v", keyName, err)
+	}
+
+	validator := map[string]interface{}{
+		"pubkey":                     json.RawMessage(strings.TrimSpace(string(pubKey))),
+		"amount":                     amount,
+		"moniker":                    keyName,
+		"commission-rate":            "0.1",
+		"commission-max-rate":        "0.2",
+		"commission-max-change-rate": "0.01",
+		"min-self-delegation":        "1",
+	}
+	data, err := json.MarshalIndent(validator, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling validator: %v", err)
+	}
+	validatorPath := filepath.Join(scratchHome, "validator.json")
+	if err := os.WriteFile(validatorPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing validator file: %v", err)
+	}
+
+	fmt.Printf("🏛️ Creating validator %s with %s...\n", keyName, amount)
+	return runStakingTx(cfg, keyName, "create-validator", validatorPath)
+}
+
+// Delegate delegates amount from keyName to the validator valoperAddr.
+func Delegate(cfg *ChainConfig, keyName, valoperAddr, amount string) error {
+	fmt.Printf("🤝 %s delegating %s to %s...\n", keyName, amount, valoperAddr)
+	return runStakingTx(cfg, keyName, "delegate", valoperAddr, amount)
+}
+
+// Unbond undelegates amount of keyName's delegation to valoperAddr.
+func Unbond(cfg *ChainConfig, keyName, valoperAddr, amount string) error {
+	fmt.Printf("🔓 %s unbonding %s from %s...\n", keyName, amount, valoperAddr)
+	return runStakingTx(cfg, keyName, "unbond", valoperAddr, amount)
+}
+
+// ScenarioGovernanceUnderUnbonding runs a 3-validator chain (validators 2 and
+// 3 have no nodes), unbonds validator 3 and then passes a proposal voted yes
+// only by a delegator whose stake meets quorum against the lower bonded total
+// but not against the total before unbonding, showing the tally uses the
+// bonded power at the end of voting.
+func ScenarioGovernanceUnderUnbonding(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	stakes := map[string]int64{
+		"unbonding-val2":  unbondingVal2Stake,
+		"unbonding-val3":  unbondingVal3Stake,
+		"unbonding-voter": unbondingVoterStake,
+	}
+	for _, keyName := range []string{"unbonding-val2", "unbonding-val3", "unbonding-voter"} {
+		if err := EnsureKey(cfg, keyName); err != nil {
+			return err
+		}
+		address, err := KeyAddress(cfg, keyName)
+		if err != nil {
+			return fmt.Errorf("error looking up %s address: %v", keyName, err)
+		}
+		sendResponse, err := BankSend(cfg, address, fmt.Sprintf("%d%s", stakes[keyName]+unbondingFundingFees, cfg.Denom))
+		if err != nil {
+			return err
+		}
+		if _, err := WaitForTx(cfg, sendResponse.TxHash, 30*time.Second); err != nil {
+			return err
+		}
+	}
+
+	for _, keyName := range []string{"unbonding-val2", "unbonding-val3"} {
+		if err := CreateValidator(cfg, keyName, fmt.Sprintf("%d%s", stakes[keyName], cfg.Denom)); err != nil {
+			return err
+		}
+	}
+	val1Addr, err := ValidatorOperatorAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return err
+	}
+	if err := Delegate(cfg, "unbonding-voter", val1Addr, fmt.Sprintf("%d%s", unbondingVoterStake, cfg.Denom)); err != nil {
+		return err
+	}
+
+	bondedBefore, err := FetchBondedTokens(cfg.RestEndpoint)
+	if err != nil {
+		return err
+	}
+	val3Addr, err := ValidatorOperatorAddress(cfg, "unbonding-val3")
+	if err != nil {
+		return err
+	}
+	if err := Unbond(cfg, "unbonding-val3", val3Addr, fmt.Sprintf("%d%s", unbondingVal3Stake, cfg.Denom)); err != nil {
+		return err
+	}
+	// The validator leaves the bonded set at the end of the block
+	height, err := LatestHeight(cfg.RPCEndpoint)
+	if err != nil {
+		return err
+	}
+	if err := WaitForHeight(cfg.RPCEndpoint, height+2, 30*time.Second); err != nil {
+		return err
+	}
+	bondedAfter, err := FetchBondedTokens(cfg.RestEndpoint)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📉 Bonded tokens: %.0f before unbonding, %.0f after\n", bondedBefore, bondedAfter)
+	if bondedBefore-bondedAfter < unbondingVal3Stake {
+		return fmt.Errorf("bonded tokens fell by %.0f after unbonding validator 3, expected at least %d", bondedBefore-bondedAfter, unbondingVal3Stake)
+	}
+
+	params, err := FetchTallyParams(cfg.RestEndpoint)
+	if err != nil {
+		return err
+	}
+	quorum := parseAmount(params.Quorum)
+	if unbondingVoterStake/bondedBefore >= quorum || unbondingVoterStake/bondedAfter < quorum {
+		return fmt.Errorf("voter stake %d does not separate quorum %s before (%.0f bonded) and after (%.0f bonded) unbonding; adjust the scenario stakes", unbondingVoterStake, params.Quorum, bondedBefore, bondedAfter)
+	}
+
+	txResponse, err := SubmitProposal(cfg, NewBridgeProposal("", cfg.Expedited), filepath.Join(cfg.Home(), "unbonding_proposal.json"))
+	if err != nil {
+		return err
+	}
+	result, err := WaitForTx(cfg, txResponse.TxHash, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	proposalID, err := ProposalIDFromTx(result)
+	if err != nil {
+		return err
+	}
+
+	voterCfg := *cfg
+	voterCfg.KeyName = "unbonding-voter"
+	fmt.Printf("🗳️  Voting yes on proposal %s from unbonding-voter only...\n", proposalID)
+	voteResponse, err := Vote(&voterCfg, proposalID, "yes")
+	if err != nil {
+		return err
+	}
+	if _, err := WaitForTx(cfg, voteResponse.TxHash, 30*time.Second); err != nil {
+		return err
+	}
+
+	info, err := FetchProposal(cfg.RestEndpoint, proposalID)
+	if err != nil {
+		return fmt.Errorf("error fetching proposal %s: %v", proposalID, err)
+	}
+	if err := WaitForVotingPeriod(context.Background(), cfg, info); err != nil {
+		return err
+	}
+	info, err = WaitForProposalFinal(cfg.RestEndpoint, proposalID, 2*time.Minute)
+	if err != nil {
+		return err
+	}
+	if err := CheckProposalOutcome(info); err != nil {
+		reason, _ := ExtractRejectionReason(cfg.RestEndpoint, proposalID)
+		return fmt.Errorf("%w (%s); quorum was not measured against the reduced bonded power", err, reason)
+	}
+	fmt.Printf("✅ Proposal %s passed: turnout %.1f%% of the reduced bonded power (%.1f%% of the power before unbonding) met quorum %s\n",
+		proposalID, 100*unbondingVoterStake/bondedAfter, 100*unbondingVoterStake/bondedBefore, params.Quorum)
+	return nil
+}
@@ -0,0 +1,117 @@
+package junctiontest
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// PruningStrategies are the values junctiond start accepts for --pruning.
+var PruningStrategies = []string{"default", "nothing", "everything", "custom"}
+
+// logLevels are the levels accepted by --log_level, alone or as module:level.
+var logLevels = []string{"trace", "debug", "info", "warn", "error", "fatal", "panic", "disabled"}
+
+var startFlagName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// reservedStartFlags are set by the tool itself, so overriding them through
+// StartFlags would silently break the chain it waits on.
+var reservedStartFlags = map[string]bool{
+	"home":               true,
+	"minimum-gas-prices": true,
+}
+
+// StartArgs returns the arguments for junctiond start: the minimum gas
+// price, then the flags from Pruning, SkipInvariants and LogLevel, then
+// StartFlags. Each StartFlags entry is "--flag", "--flag=value" or
+// "--flag value"; a flag may only be given once.
+func StartArgs(cfg *ChainConfig) ([]string, error) {
+	var flags []string
+	if cfg.Pruning != "" {
+		if !slices.Contains(PruningStrategies, cfg.Pruning) {
+			return nil, fmt.Errorf("invalid pruning %q (expected one of %s)", cfg.Pruning, strings.Join(PruningStrategies, ", "))
+		}
+		flags = append(flags, "--pruning="+cfg.Pruning)
+	}
+	if cfg.SkipInvariants {
+		flags = append(flags, "--x-crisis-skip-assert-invariants")
+	}
+	if cfg.LogLevel != "" {
+		if err := validateLogLevel(cfg.LogLevel); err != nil {
+			return nil, err
+		}
+		flags = append(flags, "--log_level="+cfg.LogLevel)
+	}
+	for _, entry := range cfg.StartFlags {
+		parsed, err := parseStartFlag(entry)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, parsed...)
+	}
+
+	seen := map[string]bool{}
+	for _, flag := range flags {
+		name, _, _ := strings.Cut(strings.TrimPrefix(flag, "--"), "=")
+		if reservedStartFlags[name] {
+			return nil, fmt.Errorf("start flag --%s is set by junction-bridge and cannot be overridden", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("start flag --%s is given more than once", name)
+		}
+		seen[name] = true
+	}
+	return append([]string{"start", "--minimum-gas-prices", cfg.MinimumGasPrices}, flags...), nil
+}
+
+// parseStartFlag turns one StartFlags entry into a single --name or
+// --name=value argument.
+func parseStartFlag(entry string) ([]string, error) {
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if len(fields) > 2 || !strings.HasPrefix(fields[0], "--") {
+		return nil, fmt.Errorf("invalid start flag %q (expected --flag, --flag=value or --flag value)", entry)
+	}
+	name, value, hasValue := strings.Cut(strings.TrimPrefix(fields[0], "--"), "=")
+	if !startFlagName.MatchString(name) {
+		return nil, fmt.Errorf("invalid start flag %q: bad flag name %q", entry, name)
+	}
+	if len(fields) == 2 {
+		if hasValue || strings.HasPrefix(fields[1], "-") {
+			return nil, fmt.Errorf("invalid start flag %q (expected --flag, --flag=value or --flag value)", entry)
+		}
+		value, hasValue = fields[1], true
+	}
+	switch name {
+	case "pruning":
+		if !slices.Contains(PruningStrategies, value) {
+			return nil, fmt.Errorf("invalid start flag %q: pruning must be one of %s", entry, strings.Join(PruningStrategies, ", "))
+		}
+	case "log_level":
+		if err := validateLogLevel(value); err != nil {
+			return nil, err
+		}
+	}
+	if !hasValue {
+		return []string{"--" + name}, nil
+	}
+	return []string{"--" + name + "=" + value}, nil
+}
+
+// validateLogLevel accepts a single level such as "info" or a
+// comma-separated list of module:level pairs such as "consensus:debug,*:error".
+func validateLogLevel(level string) error {
+	if slices.Contains(logLevels, level) {
+		return nil
+	}
+	for _, part := range strings.Split(level, ",") {
+		module, lvl, ok := strings.Cut(part, ":")
+		if !ok || module == "" || !slices.Contains(logLevels, lvl) {
+			return fmt.Errorf("invalid log_level %q (expected one of %s, or module:level pairs)", level, strings.Join(logLevels, ", "))
+		}
+	}
+	return nil
+}
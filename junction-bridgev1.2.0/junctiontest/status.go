@@ -0,0 +1,79 @@
+package junctiontest
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+)
+
+// SyncState describes how far a node is from being usable.
+type SyncState int
+
+const (
+	// SyncStateNotStarted means the RPC endpoint refused the connection.
+	SyncStateNotStarted SyncState = iota
+	// SyncStateSyncing means the node is up but still catching up.
+	SyncStateSyncing
+	// SyncStateSynced means the node has caught up with its peers.
+	SyncStateSynced
+)
+
+func (s SyncState) String() string {
+	switch s {
+	case SyncStateNotStarted:
+		return "not started"
+	case SyncStateSyncing:
+		return "syncing"
+	case SyncStateSynced:
+		return "synced"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+}
+
+// QuerySyncState reports the node's sync state. Errors other than a refused
+// connection are returned as-is.
+func QuerySyncState(rpcURL string) (SyncState, error) {
+	status, err := FetchStatus(rpcURL)
+	Metrics.SetChainUp(err == nil && !status.Result.SyncInfo.CatchingUp)
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return SyncStateNotStarted, nil
+		}
+		return SyncStateNotStarted, err
+	}
+	if status.Result.SyncInfo.CatchingUp {
+		return SyncStateSyncing, nil
+	}
+	return SyncStateSynced, nil
+}
+
+// WaitForSync polls the node's /status endpoint until it reports
+// catching_up == false, printing each state change along the way. Polls are
+// spaced by backoff.
+func WaitForSync(rpcURL string, timeout time.Duration, backoff Backoff) error {
+	deadline := time.Now().Add(timeout)
+	lastState := SyncState(-1)
+
+	for attempt := 0; ; attempt++ {
+		state, err := QuerySyncState(rpcURL)
+		if err == nil {
+			if state != lastState {
+				fmt.Printf("🔄 Node at %s: %s\n", rpcURL, state)
+				lastState = state
+			}
+			if state == SyncStateSynced {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("%w: node at %s not synced after %s: %v", ErrChainNotReady, rpcURL, timeout, err)
+			}
+			return fmt.Errorf("%w: node at %s not synced after %s (state: %s)", ErrChainNotReady, rpcURL, timeout, lastState)
+		}
+		time.Sleep(backoff.Delay(attempt))
+	}
+}
@@ -0,0 +1,142 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// TallyParams are the gov module's vote thresholds, as decimal fractions.
+type TallyParams struct {
+	Quorum             string `json:"quorum"`
+	Threshold          string `json:"threshold"`
+	VetoThreshold      string `json:"veto_threshold"`
+	ExpeditedThreshold string `json:"expedited_threshold"`
+}
+
+// FetchTallyParams returns the chain's governance tally parameters.
+func FetchTallyParams(restEndpoint string) (*TallyParams, error) {
+	var response struct {
+		Params      *TallyParams `json:"params"`
+		TallyParams *TallyParams `json:"tally_params"`
+	}
+	if err := getJSON(restEndpoint+"/cosmos/gov/v1/params/tallying", &response); err != nil {
+		return nil, fmt.Errorf("error fetching tally params: %v", err)
+	}
+	if response.Params != nil {
+		return response.Params, nil
+	}
+	if response.TallyParams != nil {
+		return response.TallyParams, nil
+	}
+	return nil, fmt.Errorf("no tally params in response")
+}
+
+// FetchBondedTokens returns the total bonded stake, used as the quorum base.
+func FetchBondedTokens(restEndpoint string) (float64, error) {
+	var response struct {
+		Pool struct {
+			BondedTokens string `json:"bonded_tokens"`
+		} `json:"pool"`
+	}
+	if err := getJSON(restEndpoint+"/cosmos/staking/v1beta1/pool", &response); err != nil {
+		return 0, fmt.Errorf("error fetching staking pool: %v", err)
+	}
+	return strconv.ParseFloat(response.Pool.BondedTokens, 64)
+}
+
+// ExtractRejectionReason explains why a finished proposal did not pass by
+// comparing its final tally against the chain's tally parameters. Bonded
+// stake is read at query time, so the quorum check is approximate if stake
+// changed after voting ended.
+func ExtractRejectionReason(restEndpoint, proposalID string) (string, error) {
+	proposal, err := FetchProposal(restEndpoint, proposalID)
+	if err != nil {
+		return "", fmt.Errorf("error fetching proposal %s: %v", proposalID, err)
+	}
+
+	switch proposal.Status {
+	case "PROPOSAL_STATUS_PASSED":
+		return "Passed", nil
+	case "PROPOSAL_STATUS_FAILED":
+		if proposal.FailedReason != "" {
+			return "Failed: " + proposal.FailedReason, nil
+		}
+		return "Failed: proposal messages could not be executed", nil
+	case "PROPOSAL_STATUS_REJECTED":
+	default:
+		return "", fmt.Errorf("proposal %s has not finished (status %s)", proposalID, proposal.Status)
+	}
+
+	params, err := FetchTallyParams(restEndpoint)
+	if err != nil {
+		return "", err
+	}
+	bonded, err := FetchBondedTokens(restEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	tally := proposal.FinalTallyResult
+	yes := parseAmount(tally.YesCount)
+	no := parseAmount(tally.NoCount)
+	abstain := parseAmount(tally.AbstainCount)
+	veto := parseAmount(tally.NoWithVetoCount)
+	total := yes + no + abstain + veto
+
+	threshold := params.Threshold
+	if proposal.Expedited && params.ExpeditedThreshold != "" {
+		threshold = params.ExpeditedThreshold
+	}
+
+	switch {
+	case bonded == 0 || total/bonded < parseAmount(params.Quorum):
+		return "Rejected: quorum not reached", nil
+	case veto/total > parseAmount(params.VetoThreshold):
+		return "Rejected: veto threshold exceeded", nil
+	case total == abstain || yes/(total-abstain) <= parseAmount(threshold):
+		return "Rejected: yes votes below threshold", nil
+	default:
+		return "Rejected: no tally threshold explains the result", nil
+	}
+}
+
+// parseAmount parses a decimal amount, treating unparsable values as zero.
+func parseAmount(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// getJSON decodes the JSON body of a GET request to url into v.
+func getJSON(url string, v interface{}) error {
+	return getJSONAtHeight(url, 0, v)
+}
+
+// getJSONAtHeight is getJSON for a REST query evaluated at block height, or
+// at the latest block if height is 0.
+func getJSONAtHeight(url string, height int64, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if height > 0 {
+		req.Header.Set("x-cosmos-block-height", strconv.FormatInt(height, 10))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,144 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ParseTomlOverrides parses "key=value" pairs separated by commas, such as
+// "api.enable=true,pruning=nothing", into dotted keys and raw values. A
+// comma-separated part without "=" continues the previous value, so coin
+// lists like "minimum-gas-prices=1uamf,2utest" need no quoting.
+func ParseTomlOverrides(spec string) (map[string]string, error) {
+	overrides := map[string]string{}
+	lastKey := ""
+	for _, part := range strings.Split(spec, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			if lastKey == "" {
+				return nil, fmt.Errorf("invalid override %q (expected key=value)", part)
+			}
+			overrides[lastKey] += "," + part
+			continue
+		}
+		lastKey = strings.TrimSpace(key)
+		overrides[lastKey] = strings.TrimSpace(value)
+	}
+	return overrides, nil
+}
+
+// EditAppToml applies overrides to the node's config/app.toml.
+func EditAppToml(homeDir string, overrides map[string]string) error {
+	return EditTomlFile(filepath.Join(homeDir, "config", "app.toml"), overrides)
+}
+
+// EditConfigToml applies overrides to the node's config/config.toml.
+func EditConfigToml(homeDir string, overrides map[string]string) error {
+	return EditTomlFile(filepath.Join(homeDir, "config", "config.toml"), overrides)
+}
+
+// EditTomlFile sets each dotted key in overrides (e.g. "grpc.enable") in the
+// TOML file at path. Values are converted to the type of the existing value;
+// new keys are read as a bool or number where possible, otherwise a string.
+// Comments in the file are not preserved.
+func EditTomlFile(path string, overrides map[string]string) error {
+	return updateTomlFile(path, sortedKeys(overrides), func(key string, existing interface{}) (interface{}, error) {
+		return tomlValue(existing, overrides[key])
+	})
+}
+
+// setTomlValues sets each dotted key in values in the TOML file at path,
+// using the values as given.
+func setTomlValues(path string, values map[string]interface{}) error {
+	return updateTomlFile(path, sortedKeys(values), func(key string, existing interface{}) (interface{}, error) {
+		return values[key], nil
+	})
+}
+
+// updateTomlFile rewrites the TOML file at path, setting each dotted key to
+// the result of value, which is given the key's current value (nil if unset).
+func updateTomlFile(path string, keys []string, value func(key string, existing interface{}) (interface{}, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+	var document map[string]interface{}
+	if err := toml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		table := document
+		for _, name := range parts[:len(parts)-1] {
+			next, ok := table[name].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: %s is not a table", path, name)
+			}
+			table = next
+		}
+
+		last := parts[len(parts)-1]
+		updated, err := value(key, table[last])
+		if err != nil {
+			return fmt.Errorf("%s: %s: %v", path, key, err)
+		}
+		table[last] = updated
+		fmt.Printf("   %s = %v\n", key, updated)
+	}
+
+	out, err := toml.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// tomlValue converts raw to the type of existing, or guesses a type when
+// there is no existing value.
+func tomlValue(existing interface{}, raw string) (interface{}, error) {
+	switch existing.(type) {
+	case bool:
+		return strconv.ParseBool(raw)
+	case int64:
+		return strconv.ParseInt(raw, 10, 64)
+	case float64:
+		return strconv.ParseFloat(raw, 64)
+	case string:
+		return raw, nil
+	case nil:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b, nil
+		}
+		if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f, nil
+		}
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("cannot override a %T value", existing)
+	}
+}
@@ -0,0 +1,315 @@
+package junctiontest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TxResponse struct {
+	TxHash    string `json:"txhash"`
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+	RawLog    string `json:"raw_log"`
+}
+
+type TxResult struct {
+	Height    int64  `json:"height,string"`
+	TxHash    string `json:"txhash"`
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+	RawLog    string `json:"raw_log"`
+	GasWanted int64  `json:"gas_wanted,string"`
+	GasUsed   int64  `json:"gas_used,string"`
+	Events    []struct {
+		Type       string `json:"type"`
+		Attributes []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"attributes"`
+	} `json:"events"`
+}
+
+// ValidVoteOptions are the vote options accepted by Vote.
+var ValidVoteOptions = []string{"yes", "no", "abstain", "no_with_veto"}
+
+// Fees used for each tx type when ChainConfig.Fees is empty.
+const (
+	DefaultSubmitFees = "500uamf"
+	DefaultVoteFees   = "50uamf"
+	DefaultSendFees   = "50uamf"
+)
+
+// TxFees returns the configured fees, or defaultFees if none are set.
+func TxFees(cfg *ChainConfig, defaultFees string) string {
+	if cfg.Fees == "" {
+		return defaultFees
+	}
+	return cfg.Fees
+}
+
+// TxGasFlags builds the --gas/--gas-adjustment/--fees flags for a tx from
+// the configured gas strategy. defaultFees is used when no fees are configured.
+func TxGasFlags(cfg *ChainConfig, defaultFees string) ([]string, error) {
+	fees := TxFees(cfg, defaultFees)
+
+	switch cfg.GasMode {
+	case "auto":
+		return []string{
+			"--gas", "auto",
+			"--gas-adjustment", strconv.FormatFloat(cfg.GasAdjustment, 'f', -1, 64),
+			"--fees", fees,
+		}, nil
+	case "fixed":
+		return []string{
+			"--gas", strconv.FormatUint(cfg.GasLimit, 10),
+			"--fees", fees,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid gas_mode %q (expected auto or fixed)", cfg.GasMode)
+	}
+}
+
+// RunTxCommand runs a junctiond tx command, echoing its output, and prints a
+// link to the broadcast transaction. If the tx is rejected with a non-zero
+// code, the response is returned together with an error wrapping ErrTxFailed.
+func RunTxCommand(cfg *ChainConfig, cmd *exec.Cmd) (*TxResponse, error) {
+	var stdout bytes.Buffer
+	cmd.Stdout = io.MultiWriter(os.Stdout, &stdout)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+
+	var txResponse TxResponse
+	if err := json.Unmarshal(stdout.Bytes(), &txResponse); err != nil {
+		return nil, fmt.Errorf("error parsing tx response: %v", err)
+	}
+
+	fmt.Printf("\n🔗 Transaction: %s\n", FormatExplorerURL(cfg.ExplorerURL, txResponse.TxHash))
+	if txResponse.Code != 0 {
+		return &txResponse, txFailure(txResponse.TxHash, txResponse.Codespace, txResponse.Code, txResponse.RawLog)
+	}
+	recordIncludedTx(stdout.Bytes())
+	return &txResponse, nil
+}
+
+// FormatExplorerURL returns the block explorer link for txHash, or the raw
+// hash when no explorer is configured.
+func FormatExplorerURL(explorerBaseURL, txHash string) string {
+	if explorerBaseURL == "" {
+		return txHash
+	}
+	return strings.TrimRight(explorerBaseURL, "/") + "/" + txHash
+}
+
+// WaitForTx polls junctiond until txHash is included in a block or the
+// timeout elapses. A tx broadcast in block mode already carries its result,
+// so that is used without polling. With Confirmations above 1 it then also
+// waits until that many blocks, counting the inclusion block, have been
+// produced.
+func WaitForTx(cfg *ChainConfig, txHash string, timeout time.Duration) (*TxResult, error) {
+	return WaitForTxContext(context.Background(), cfg, txHash, timeout)
+}
+
+// WaitForTxContext is WaitForTx that also returns ctx's error as soon as ctx
+// is cancelled.
+func WaitForTxContext(ctx context.Context, cfg *ChainConfig, txHash string, timeout time.Duration) (*TxResult, error) {
+	if result, ok := takeIncludedTx(txHash); ok {
+		if err := waitForConfirmations(ctx, cfg, result.Height); err != nil {
+			return &result, fmt.Errorf("tx %s included at height %d but not confirmed: %w", txHash, result.Height, err)
+		}
+		return &result, nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := checkChainAlive(); err != nil {
+			return nil, err
+		}
+
+		out, err := JunctiondCommand(cfg, "query", "tx", txHash, "--output", "json").Output()
+		if err == nil {
+			var result TxResult
+			if err := json.Unmarshal(out, &result); err != nil {
+				return nil, fmt.Errorf("error parsing tx %s: %v", txHash, err)
+			}
+			if result.Code != 0 {
+				return &result, txFailure(txHash, result.Codespace, result.Code, result.RawLog)
+			}
+			if err := waitForConfirmations(ctx, cfg, result.Height); err != nil {
+				return &result, fmt.Errorf("tx %s included at height %d but not confirmed: %w", txHash, result.Height, err)
+			}
+			return &result, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for tx %s to be included", txHash)
+		}
+		if err := sleepContext(ctx, time.Second); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitForConfirmations waits until the chain is cfg.Confirmations-1 blocks
+// past includedHeight, allowing 30s per block.
+func waitForConfirmations(ctx context.Context, cfg *ChainConfig, includedHeight int64) error {
+	if cfg.Confirmations <= 1 {
+		return nil
+	}
+	extra := int64(cfg.Confirmations - 1)
+	fmt.Printf("⏳ Waiting for %d confirmations...\n", cfg.Confirmations)
+	return WaitForHeightContext(ctx, cfg.RPCEndpoint, includedHeight+extra, time.Duration(extra)*30*time.Second)
+}
+
+// SubmitProposal writes proposal to proposalPath and broadcasts it.
+func SubmitProposal(cfg *ChainConfig, proposal Proposal, proposalPath string) (*TxResponse, error) {
+	if err := CheckProposerBalance(cfg, proposal.Deposit); err != nil {
+		return nil, err
+	}
+	if err := WriteProposalFileFormat(proposalPath, proposal, cfg.ProposalFormat); err != nil {
+		return nil, err
+	}
+	return SubmitProposalFile(cfg, proposalPath)
+}
+
+// SubmitProposalFile broadcasts the proposal in proposalPath from
+// cfg.KeyName after checking ChainID is a test chain. The submit command
+// matches ProposalFormat: `tx gov submit-proposal` for v1 files (in the
+// form DetectGovVersion finds the binary expects) or the legacy submit
+// command for legacy files. Gas and fee flags come from TxGasFlags and
+// --broadcast-mode from BroadcastMode. With SimulateFirst set, the tx is
+// dry-run first and nothing is broadcast if the simulation fails.
+func SubmitProposalFile(cfg *ChainConfig, proposalPath string) (*TxResponse, error) {
+	if err := CheckTestChain(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.SimulateFirst {
+		fmt.Println("🧪 Simulating proposal tx...")
+		gas, err := SimulateProposalFile(cfg, proposalPath)
+		if err != nil {
+			return nil, fmt.Errorf("simulation failed, not submitting: %w", err)
+		}
+		fmt.Printf("✅ Simulation passed, estimated gas: %d\n", gas)
+	}
+
+	gasArgs, err := TxGasFlags(cfg, DefaultSubmitFees)
+	if err != nil {
+		return nil, err
+	}
+	submitArgs, err := submitProposalArgs(cfg, proposalPath)
+	if err != nil {
+		return nil, err
+	}
+	broadcastArgs, err := broadcastModeArgs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	submitArgs = append(submitArgs,
+		"--from", cfg.KeyName,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--output", "json",
+		"-y",
+	)
+	submitArgs = append(submitArgs, gasArgs...)
+	submitArgs = append(submitArgs, broadcastArgs...)
+
+	return RunTxCommand(cfg, JunctiondCommand(cfg, submitArgs...))
+}
+
+// BankSend sends amount from cfg.KeyName to toAddress.
+func BankSend(cfg *ChainConfig, toAddress, amount string) (*TxResponse, error) {
+	gasArgs, err := TxGasFlags(cfg, DefaultSendFees)
+	if err != nil {
+		return nil, err
+	}
+	sendArgs := append([]string{
+		"tx", "bank", "send", cfg.KeyName, toAddress, amount,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--output", "json",
+		"-y",
+	}, gasArgs...)
+
+	return RunTxCommand(cfg, JunctiondCommand(cfg, sendArgs...))
+}
+
+// Vote casts voteOption on proposalID from cfg.KeyName using the configured
+// broadcast mode.
+func Vote(cfg *ChainConfig, proposalID, voteOption string) (*TxResponse, error) {
+	if err := ValidateVoteOption(voteOption); err != nil {
+		return nil, err
+	}
+
+	gasArgs, err := TxGasFlags(cfg, DefaultVoteFees)
+	if err != nil {
+		return nil, err
+	}
+	broadcastArgs, err := broadcastModeArgs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	voteArgs := append([]string{
+		"tx", "gov", "vote", proposalID, voteOption,
+		"--from", cfg.KeyName,
+		"--chain-id", cfg.ChainID,
+		"--keyring-backend", "os",
+		"--output", "json",
+		"-y",
+	}, gasArgs...)
+	voteArgs = append(voteArgs, broadcastArgs...)
+
+	return RunTxCommand(cfg, JunctiondCommand(cfg, voteArgs...))
+}
+
+// VoteResult is the outcome of one key's vote in VoteFromAll.
+type VoteResult struct {
+	Key    string
+	TxHash string
+	Result *TxResult
+	Err    error
+}
+
+// VoteFromAll casts voteOption on proposalID from each key in turn. Every
+// vote is waited on until it is included before the next is sent, so keys
+// that share an account (or a repeated key) never race for the same account
+// sequence. A failing key does not stop the others; check each result's Err.
+func VoteFromAll(cfg *ChainConfig, proposalID string, keys []string, voteOption string) []VoteResult {
+	results := make([]VoteResult, 0, len(keys))
+	for _, key := range keys {
+		keyCfg := *cfg
+		keyCfg.KeyName = key
+
+		fmt.Printf("🗳️  Voting %s on proposal %s from %s...\n", voteOption, proposalID, key)
+		result := VoteResult{Key: key}
+		txResponse, err := Vote(&keyCfg, proposalID, voteOption)
+		if txResponse != nil {
+			result.TxHash = txResponse.TxHash
+		}
+		if err == nil {
+			result.Result, err = Gas.WaitForTx(&keyCfg, "vote", txResponse.TxHash, 30*time.Second)
+		}
+		result.Err = err
+		results = append(results, result)
+	}
+	return results
+}
+
+// ValidateVoteOption checks voteOption is one of ValidVoteOptions.
+func ValidateVoteOption(voteOption string) error {
+	for _, option := range ValidVoteOptions {
+		if voteOption == option {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid vote option: %s. Valid options are: %s", voteOption, strings.Join(ValidVoteOptions, ", "))
+}
@@ -0,0 +1,68 @@
+package junctiontest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatExplorerURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		txHash  string
+		want    string
+	}{
+		{"no explorer", "", "ABC123", "ABC123"},
+		{"base without slash", "https://explorer.example/tx", "ABC123", "https://explorer.example/tx/ABC123"},
+		{"base with slash", "https://explorer.example/tx/", "ABC123", "https://explorer.example/tx/ABC123"},
+		{"base with several slashes", "https://explorer.example/tx//", "ABC123", "https://explorer.example/tx/ABC123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatExplorerURL(tt.baseURL, tt.txHash); got != tt.want {
+				t.Errorf("FormatExplorerURL(%q, %q) = %q, want %q", tt.baseURL, tt.txHash, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTxGasFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ChainConfig
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "auto with default fees",
+			cfg:  ChainConfig{GasMode: "auto", GasAdjustment: 1.5},
+			want: []string{"--gas", "auto", "--gas-adjustment", "1.5", "--fees", "50uamf"},
+		},
+		{
+			name: "auto with configured fees",
+			cfg:  ChainConfig{GasMode: "auto", GasAdjustment: 2, Fees: "1000uamf"},
+			want: []string{"--gas", "auto", "--gas-adjustment", "2", "--fees", "1000uamf"},
+		},
+		{
+			name: "fixed",
+			cfg:  ChainConfig{GasMode: "fixed", GasLimit: 300000},
+			want: []string{"--gas", "300000", "--fees", "50uamf"},
+		},
+		{
+			name:    "unknown mode",
+			cfg:     ChainConfig{GasMode: "manual"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TxGasFlags(&tt.cfg, "50uamf")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TxGasFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TxGasFlags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
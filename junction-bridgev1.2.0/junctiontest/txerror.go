@@ -0,0 +1,79 @@
+package junctiontest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TxError is a failed tx's raw log decoded into its error category and a
+// readable explanation.
+type TxError struct {
+	Codespace    string
+	Code         uint32
+	HumanMessage string
+	RawLog       string
+}
+
+func (e *TxError) Error() string {
+	if e.Codespace == "" {
+		return e.HumanMessage
+	}
+	return fmt.Sprintf("%s (%s code %d)", e.HumanMessage, e.Codespace, e.Code)
+}
+
+// knownTxErrors maps "codespace:code" to a readable explanation.
+var knownTxErrors = map[string]string{
+	"sdk:2":  "transaction could not be decoded",
+	"sdk:3":  "account sequence mismatch; another tx from this key may be pending",
+	"sdk:4":  "signer is not authorized to perform this action",
+	"sdk:5":  "insufficient funds to pay for the tx",
+	"sdk:7":  "invalid address",
+	"sdk:9":  "account not found; fund it before sending txs from it",
+	"sdk:10": "invalid coin amount or denomination",
+	"sdk:11": "out of gas; raise gas_adjustment or gas_limit",
+	"sdk:13": "fee too low for the node's minimum gas prices; raise fees",
+	"sdk:19": "tx already in the mempool",
+	"sdk:21": "tx too large",
+	"sdk:32": "account sequence mismatch; another tx from this key may be pending",
+	"gov:2":  "proposal does not exist",
+	"gov:3":  "proposal is no longer accepting deposits or votes",
+	"gov:5":  "invalid proposal content",
+	"gov:7":  "invalid vote option",
+	"gov:12": "a proposal message is invalid",
+	"gov:13": "proposal message signer must be the gov module authority",
+	"gov:15": "proposal metadata is too long",
+	"gov:16": "initial deposit is below the minimum",
+}
+
+var codespacePattern = regexp.MustCompile(`codespace:? (\w+),? code:? (\d+)`)
+
+// ParseTxError decodes a raw log such as "codespace sdk code 11: out of gas"
+// into a TxError. Logs without a codespace keep their last message segment
+// as the explanation.
+func ParseTxError(rawLog string) TxError {
+	txErr := TxError{RawLog: rawLog}
+
+	if match := codespacePattern.FindStringSubmatch(rawLog); match != nil {
+		code, _ := strconv.ParseUint(match[2], 10, 32)
+		txErr.Codespace = match[1]
+		txErr.Code = uint32(code)
+	}
+
+	if message, ok := knownTxErrors[fmt.Sprintf("%s:%d", txErr.Codespace, txErr.Code)]; ok {
+		txErr.HumanMessage = message
+		return txErr
+	}
+
+	// Raw logs nest context as "outer: inner: cause"; the cause reads best
+	message := rawLog
+	if i := strings.LastIndex(message, ": "); i >= 0 {
+		message = message[i+2:]
+	}
+	txErr.HumanMessage = strings.TrimSpace(message)
+	if txErr.HumanMessage == "" {
+		txErr.HumanMessage = "transaction failed"
+	}
+	return txErr
+}
@@ -0,0 +1,68 @@
+package junctiontest
+
+import "testing"
+
+func TestParseTxError(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawLog    string
+		codespace string
+		code      uint32
+		message   string
+		errString string
+	}{
+		{
+			name:      "known sdk error",
+			rawLog:    "codespace sdk code 11: out of gas in location: WriteFlat; gasWanted: 200000, gasUsed: 200345: out of gas",
+			codespace: "sdk",
+			code:      11,
+			message:   "out of gas; raise gas_adjustment or gas_limit",
+			errString: "out of gas; raise gas_adjustment or gas_limit (sdk code 11)",
+		},
+		{
+			name:      "colon separated codespace",
+			rawLog:    "codespace: gov, code: 16: initial deposit too low",
+			codespace: "gov",
+			code:      16,
+			message:   "initial deposit is below the minimum",
+			errString: "initial deposit is below the minimum (gov code 16)",
+		},
+		{
+			name:      "unknown code keeps the cause",
+			rawLog:    "codespace evmbridge code 4: failed to execute message: worker already registered",
+			codespace: "evmbridge",
+			code:      4,
+			message:   "worker already registered",
+			errString: "worker already registered (evmbridge code 4)",
+		},
+		{
+			name:      "no codespace",
+			rawLog:    "failed to execute message; message index: 0: signature verification failed",
+			message:   "signature verification failed",
+			errString: "signature verification failed",
+		},
+		{
+			name:      "empty log",
+			rawLog:    "",
+			message:   "transaction failed",
+			errString: "transaction failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTxError(tt.rawLog)
+			if got.Codespace != tt.codespace || got.Code != tt.code {
+				t.Errorf("ParseTxError(%q) codespace/code = %s/%d, want %s/%d", tt.rawLog, got.Codespace, got.Code, tt.codespace, tt.code)
+			}
+			if got.HumanMessage != tt.message {
+				t.Errorf("ParseTxError(%q) HumanMessage = %q, want %q", tt.rawLog, got.HumanMessage, tt.message)
+			}
+			if got.RawLog != tt.rawLog {
+				t.Errorf("ParseTxError(%q) RawLog = %q", tt.rawLog, got.RawLog)
+			}
+			if s := got.Error(); s != tt.errString {
+				t.Errorf("ParseTxError(%q).Error() = %q, want %q", tt.rawLog, s, tt.errString)
+			}
+		})
+	}
+}
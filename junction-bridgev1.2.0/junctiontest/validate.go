@@ -0,0 +1,212 @@
+package junctiontest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// AddressPrefix is the bech32 human-readable part of junction account
+// addresses.
+const AddressPrefix = "air"
+
+// bech32Charset is the bech32 data alphabet (BIP 173).
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// ValidateBech32Address checks addr is a well-formed bech32 address with
+// the given prefix and a valid checksum. Errors wrap ErrInvalidAddress.
+func ValidateBech32Address(addr, prefix string) error {
+	if addr != strings.ToLower(addr) && addr != strings.ToUpper(addr) {
+		return fmt.Errorf("%w: %s mixes upper and lower case", ErrInvalidAddress, addr)
+	}
+	lower := strings.ToLower(addr)
+	sep := strings.LastIndex(lower, "1")
+	if sep < 1 || sep+7 > len(lower) {
+		return fmt.Errorf("%w: %s is not a bech32 address", ErrInvalidAddress, addr)
+	}
+	if hrp := lower[:sep]; hrp != prefix {
+		return fmt.Errorf("%w: %s has prefix %q, expected %q", ErrInvalidAddress, addr, hrp, prefix)
+	}
+
+	values := make([]int, 0, len(lower)-sep-1)
+	for _, c := range lower[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return fmt.Errorf("%w: %s contains invalid character %q", ErrInvalidAddress, addr, c)
+		}
+		values = append(values, v)
+	}
+	if bech32Polymod(append(bech32ExpandPrefix(prefix), values...)) != 1 {
+		return fmt.Errorf("%w: %s has an invalid checksum", ErrInvalidAddress, addr)
+	}
+	return nil
+}
+
+func bech32ExpandPrefix(prefix string) []int {
+	expanded := make([]int, 0, 2*len(prefix)+1)
+	for _, c := range prefix {
+		expanded = append(expanded, int(c)>>5)
+	}
+	expanded = append(expanded, 0)
+	for _, c := range prefix {
+		expanded = append(expanded, int(c)&31)
+	}
+	return expanded
+}
+
+func bech32Polymod(values []int) int {
+	generator := []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := 1
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ v
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// ValidateEVMAddress checks addr is a 0x-prefixed 20-byte hex address. The
+// EIP-55 mixed-case checksum is not verified. Errors wrap ErrInvalidAddress.
+func ValidateEVMAddress(addr string) error {
+	if !strings.HasPrefix(addr, "0x") || len(addr) != 42 {
+		return fmt.Errorf("%w: %s is not a 0x-prefixed 40 hex digit EVM address", ErrInvalidAddress, addr)
+	}
+	if _, err := hex.DecodeString(addr[2:]); err != nil {
+		return fmt.Errorf("%w: %s is not valid hex", ErrInvalidAddress, addr)
+	}
+	return nil
+}
+
+// ValidationCheck is the result of one check run by ValidateProposalFiles.
+type ValidationCheck struct {
+	Name string
+	Err  error
+}
+
+// ValidateProposalFiles runs the structural and semantic checks on a
+// proposal file and its metadata file without touching a chain: the JSON
+// parses, metadata is an ipfs:// URI (matching metadataPath for a CIDv0),
+// the deposit parses, message authorities are the gov module, bridge
+// workers are distinct air1 addresses and the bridge contract is an EVM
+// address, and the proposal's title and summary match the metadata's.
+func ValidateProposalFiles(proposalPath, metadataPath string) []ValidationCheck {
+	var checks []ValidationCheck
+	check := func(name string, err error) {
+		checks = append(checks, ValidationCheck{Name: name, Err: err})
+	}
+
+	data, err := os.ReadFile(proposalPath)
+	if err != nil {
+		check("proposal file parses", err)
+		return checks
+	}
+	var proposal Proposal
+	var raw struct {
+		Messages []json.RawMessage `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &proposal); err == nil {
+		err = json.Unmarshal(data, &raw)
+	}
+	check("proposal file parses", err)
+	if err != nil {
+		return checks
+	}
+
+	check("title and summary are set", func() error {
+		if proposal.Title == "" || proposal.Summary == "" {
+			return fmt.Errorf("title and summary must not be empty")
+		}
+		return nil
+	}())
+
+	check("deposit parses", func() error {
+		coins, err := ParseCoins(proposal.Deposit)
+		if err != nil {
+			return err
+		}
+		if len(coins) == 0 {
+			return fmt.Errorf("deposit is empty")
+		}
+		return nil
+	}())
+
+	cid := strings.TrimPrefix(proposal.Metadata, "ipfs://")
+	check("metadata is an ipfs:// URI", func() error {
+		if !strings.HasPrefix(proposal.Metadata, "ipfs://") || cid == "" {
+			return fmt.Errorf("metadata %q is not an ipfs://<cid> URI", proposal.Metadata)
+		}
+		return nil
+	}())
+
+	check("messages are present", func() error {
+		if len(proposal.Messages) == 0 {
+			return fmt.Errorf("proposal has no messages")
+		}
+		return nil
+	}())
+	for i, msg := range proposal.Messages {
+		prefix := fmt.Sprintf("message %d", i)
+		check(prefix+" has a type", func() error {
+			if msg.Type == "" {
+				return fmt.Errorf("missing @type")
+			}
+			return nil
+		}())
+		if strings.Contains(string(raw.Messages[i]), `"authority"`) {
+			check(prefix+" authority is the gov module", func() error {
+				if err := ValidateBech32Address(msg.Authority, AddressPrefix); err != nil {
+					return err
+				}
+				if msg.Authority != GovModuleAddress {
+					return fmt.Errorf("authority %s is not the gov module %s", msg.Authority, GovModuleAddress)
+				}
+				return nil
+			}())
+		}
+		if msg.Type != "/junction.evmbridge.MsgUpdateParams" {
+			continue
+		}
+		check(prefix+" bridge workers are valid", func() error {
+			workers := msg.Params.BridgeWorkers
+			if len(workers) == 0 {
+				return fmt.Errorf("no bridge workers")
+			}
+			seen := map[string]bool{}
+			var errs []error
+			for _, worker := range workers {
+				if seen[worker] {
+					errs = append(errs, fmt.Errorf("duplicate worker %s", worker))
+				}
+				seen[worker] = true
+				if err := ValidateBech32Address(worker, AddressPrefix); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		}())
+		check(prefix+" bridge contract is an EVM address", ValidateEVMAddress(msg.Params.BridgeContractAddress))
+	}
+
+	metadata, err := ReadMetadataFile(metadataPath)
+	check("metadata file parses", err)
+	if err != nil {
+		return checks
+	}
+	check("title and summary match the metadata", func() error {
+		if metadata.Title != proposal.Title || metadata.Summary != proposal.Summary {
+			return fmt.Errorf("proposal title/summary differ from %s", metadataPath)
+		}
+		return nil
+	}())
+	if err := VerifyLocalCID(metadataPath, cid); !errors.Is(err, ErrCIDNotVerifiable) {
+		check("metadata CID matches the metadata file", err)
+	}
+	return checks
+}
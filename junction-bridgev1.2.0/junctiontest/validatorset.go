@@ -0,0 +1,138 @@
+package junctiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// ValidatorSnapshot is one validator's consensus address and voting power at
+// the time of a snapshot.
+type ValidatorSnapshot struct {
+	Address     string `json:"address"`
+	VotingPower int64  `json:"voting_power"`
+}
+
+// ValidatorSetDiff describes a validator that was added, removed or changed
+// voting power between two snapshots.
+type ValidatorSetDiff struct {
+	Address string
+	Change  string // "added", "removed" or "power changed"
+	Before  int64
+	After   int64
+}
+
+func (d ValidatorSetDiff) String() string {
+	return fmt.Sprintf("%s %s (power %d -> %d)", d.Address, d.Change, d.Before, d.After)
+}
+
+// SnapshotValidatorSet returns the current CometBFT validator set from the
+// node at rpcURL, sorted by address.
+func SnapshotValidatorSet(rpcURL string) ([]ValidatorSnapshot, error) {
+	var snapshot []ValidatorSnapshot
+	for page := 1; ; page++ {
+		var response struct {
+			Result struct {
+				Validators []struct {
+					Address     string `json:"address"`
+					VotingPower string `json:"voting_power"`
+				} `json:"validators"`
+				Total string `json:"total"`
+			} `json:"result"`
+		}
+		url := fmt.Sprintf("%s/validators?page=%d&per_page=100", rpcURL, page)
+		if err := getJSON(url, &response); err != nil {
+			return nil, fmt.Errorf("%w: error fetching validator set: %v", ErrChainNotReady, err)
+		}
+
+		for _, validator := range response.Result.Validators {
+			power, err := strconv.ParseInt(validator.VotingPower, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing voting power of %s: %v", validator.Address, err)
+			}
+			snapshot = append(snapshot, ValidatorSnapshot{Address: validator.Address, VotingPower: power})
+		}
+
+		total, _ := strconv.Atoi(response.Result.Total)
+		if len(response.Result.Validators) == 0 || len(snapshot) >= total {
+			break
+		}
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Address < snapshot[j].Address })
+	return snapshot, nil
+}
+
+// CompareValidatorSets returns every validator that differs between before
+// and after. An empty result means the set is unchanged.
+func CompareValidatorSets(before, after []ValidatorSnapshot) []ValidatorSetDiff {
+	beforePower := make(map[string]int64, len(before))
+	for _, validator := range before {
+		beforePower[validator.Address] = validator.VotingPower
+	}
+	afterPower := make(map[string]int64, len(after))
+	for _, validator := range after {
+		afterPower[validator.Address] = validator.VotingPower
+	}
+
+	var diffs []ValidatorSetDiff
+	for _, validator := range before {
+		power, ok := afterPower[validator.Address]
+		switch {
+		case !ok:
+			diffs = append(diffs, ValidatorSetDiff{Address: validator.Address, Change: "removed", Before: validator.VotingPower})
+		case power != validator.VotingPower:
+			diffs = append(diffs, ValidatorSetDiff{Address: validator.Address, Change: "power changed", Before: validator.VotingPower, After: power})
+		}
+	}
+	for _, validator := range after {
+		if _, ok := beforePower[validator.Address]; !ok {
+			diffs = append(diffs, ValidatorSetDiff{Address: validator.Address, Change: "added", After: validator.VotingPower})
+		}
+	}
+	return diffs
+}
+
+// CheckValidatorSetUnchanged takes a fresh snapshot and returns an error
+// wrapping ErrValidatorSetChanged if it differs from before.
+func CheckValidatorSetUnchanged(rpcURL string, before []ValidatorSnapshot) error {
+	after, err := SnapshotValidatorSet(rpcURL)
+	if err != nil {
+		return err
+	}
+	if diffs := CompareValidatorSets(before, after); len(diffs) > 0 {
+		return fmt.Errorf("%w: %v", ErrValidatorSetChanged, diffs)
+	}
+	return nil
+}
+
+// ValidatorSnapshotPath is where the CLI keeps the validator set taken when
+// proposalID was submitted, so the monitor can compare against it later.
+func ValidatorSnapshotPath(cfg *ChainConfig, proposalID string) string {
+	return filepath.Join(cfg.Home(), fmt.Sprintf("validator_set_%s.json", proposalID))
+}
+
+// WriteValidatorSnapshot saves snapshot as JSON to path.
+func WriteValidatorSnapshot(path string, snapshot []ValidatorSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling validator set: %v", err)
+	}
+	return WriteFileAtomic(path, data)
+}
+
+// ReadValidatorSnapshot loads a snapshot written by WriteValidatorSnapshot.
+func ReadValidatorSnapshot(path string) ([]ValidatorSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot []ValidatorSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,94 @@
+package junctiontest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PinnedVersionFile records the junctiond version first used with the
+// framework so later runs notice a swapped binary.
+var PinnedVersionFile = "$HOME/.junction-test-framework/pinned_version.txt"
+
+// PinBinaryVersion writes the detected junctiond version to PinnedVersionFile
+// on first use and afterwards fails with ErrVersionMismatch if the binary
+// reports a different version. IgnoreVersionPin re-pins the current version
+// instead, for intentional upgrades.
+func PinBinaryVersion(cfg *ChainConfig) error {
+	detected := DetectJunctiondVersion(cfg)
+	if detected == "unavailable" {
+		return fmt.Errorf("%w: could not run %s version", ErrMissingDependency, cfg.JunctiondPath)
+	}
+
+	path := os.ExpandEnv(PinnedVersionFile)
+	data, err := os.ReadFile(path)
+	if err == nil && !cfg.IgnoreVersionPin {
+		pinned := strings.TrimSpace(string(data))
+		if pinned != detected {
+			return fmt.Errorf("%w: %s reports %q but %q is pinned in %s (use --ignore-version-pin to accept the new version)", ErrVersionMismatch, cfg.JunctiondPath, detected, pinned, path)
+		}
+		return nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading pinned version: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(detected+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing pinned version: %v", err)
+	}
+	fmt.Printf("📌 Pinned junctiond version %s\n", detected)
+	return nil
+}
+
+// VerifyJunctiondChecksum compares the SHA-256 of the binary at
+// JunctiondPath with JunctiondSHA256, returning an error wrapping
+// ErrChecksumMismatch if they differ. It does nothing when no checksum is
+// configured.
+func VerifyJunctiondChecksum(cfg *ChainConfig) error {
+	expected := strings.ToLower(strings.TrimSpace(cfg.JunctiondSHA256))
+	if expected == "" {
+		return nil
+	}
+
+	path, err := exec.LookPath(cfg.JunctiondPath)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrMissingDependency, cfg.JunctiondPath, err)
+	}
+	actual, err := hashFile(path)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: %s has SHA-256 %s, expected %s", ErrChecksumMismatch, path, actual, expected)
+	}
+	return nil
+}
+
+// hashFile returns the hex SHA-256 of the file at path.
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error opening %s: %v", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("error hashing %s: %v", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GenesisHash returns the hex SHA-256 of the node's config/genesis.json, so
+// runs on different machines can confirm they started from the same genesis.
+func GenesisHash(cfg *ChainConfig) (string, error) {
+	return hashFile(filepath.Join(cfg.Home(), "config", "genesis.json"))
+}
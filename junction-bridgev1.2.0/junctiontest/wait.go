@@ -0,0 +1,161 @@
+package junctiontest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Wait modes for WaitForVotingPeriod.
+const (
+	WaitModeTime   = "time"
+	WaitModeBlocks = "blocks"
+)
+
+// blockTimeSample is how many recent blocks AverageBlockTime measures over.
+const blockTimeSample = 20
+
+// FetchBlockTime returns the header time of the block at height.
+func FetchBlockTime(rpcURL string, height int64) (time.Time, error) {
+	var block struct {
+		Result struct {
+			Block struct {
+				Header struct {
+					Time time.Time `json:"time"`
+				} `json:"header"`
+			} `json:"block"`
+		} `json:"result"`
+	}
+	if err := getJSON(fmt.Sprintf("%s/block?height=%d", rpcURL, height), &block); err != nil {
+		return time.Time{}, fmt.Errorf("error fetching block %d: %v", height, err)
+	}
+	return block.Result.Block.Header.Time, nil
+}
+
+// LatestHeight returns the node's latest block height.
+func LatestHeight(rpcURL string) (int64, error) {
+	status, err := FetchStatus(rpcURL)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrChainNotReady, err)
+	}
+	return strconv.ParseInt(status.Result.SyncInfo.LatestBlockHeight, 10, 64)
+}
+
+// AverageBlockTime measures the mean block interval over the most recent
+// blocks.
+func AverageBlockTime(rpcURL string) (time.Duration, error) {
+	latest, err := LatestHeight(rpcURL)
+	if err != nil {
+		return 0, err
+	}
+	if latest < 2 {
+		return 0, fmt.Errorf("need at least 2 blocks to measure block time, have %d", latest)
+	}
+
+	first := latest - blockTimeSample
+	if first < 1 {
+		first = 1
+	}
+	start, err := FetchBlockTime(rpcURL, first)
+	if err != nil {
+		return 0, err
+	}
+	end, err := FetchBlockTime(rpcURL, latest)
+	if err != nil {
+		return 0, err
+	}
+	return end.Sub(start) / time.Duration(latest-first), nil
+}
+
+// sleepContext sleeps for d, returning ctx's error early if ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// waitChainAlive waits for d or until ctx is cancelled, checking every
+// second that the watched background chain has not crashed so a crash ends
+// the wait early.
+func waitChainAlive(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return checkChainAlive()
+		case <-ticker.C:
+			if err := checkChainAlive(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// WaitForVotingPeriod blocks until the proposal's voting end time has passed
+// or ctx is cancelled. In WaitModeBlocks it converts the remaining time into a
+// block count using the measured average block time and waits for that many
+// blocks instead, which stays correct when block times drift. If the
+// measured block time is not positive it warns and waits out the time.
+func WaitForVotingPeriod(ctx context.Context, cfg *ChainConfig, proposal *ProposalInfo) error {
+	end, err := time.Parse(time.RFC3339Nano, proposal.VotingEndTime)
+	if err != nil {
+		return fmt.Errorf("invalid voting end time %q: %v", proposal.VotingEndTime, err)
+	}
+	remaining := time.Until(end)
+	if cfg.ProposalTimeout > 0 {
+		remaining = time.Duration(cfg.ProposalTimeout) * time.Second
+		fmt.Printf("⏱️  Using proposal timeout of %s instead of the voting end time\n", remaining)
+	}
+	if remaining <= 0 {
+		return nil
+	}
+
+	switch cfg.WaitMode {
+	case WaitModeTime, "":
+		fmt.Printf("⏳ Waiting %s for the voting period to end...\n", remaining.Round(time.Second))
+		return waitChainAlive(ctx, remaining)
+	case WaitModeBlocks:
+		blockTime, err := AverageBlockTime(cfg.RPCEndpoint)
+		if err != nil {
+			return err
+		}
+		blocks, err := blocksFor(remaining, blockTime)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, waiting %s instead\n", err, remaining.Round(time.Second))
+			return waitChainAlive(ctx, remaining)
+		}
+		current, err := LatestHeight(cfg.RPCEndpoint)
+		if err != nil {
+			return err
+		}
+		target := current + blocks
+		fmt.Printf("⏳ Waiting %d blocks (avg %s/block) until height %d for the voting period to end...\n", blocks, blockTime.Round(time.Millisecond), target)
+		return WaitForHeightContext(ctx, cfg.RPCEndpoint, target, 2*remaining+time.Minute)
+	default:
+		return fmt.Errorf("invalid wait_mode %q (expected time or blocks)", cfg.WaitMode)
+	}
+}
+
+// blocksFor converts remaining into the number of blocks to wait at
+// blockTime per block, plus one so the end-of-voting tally has been applied.
+// A non-positive blockTime, e.g. from sampled headers sharing a timestamp,
+// cannot be converted.
+func blocksFor(remaining, blockTime time.Duration) (int64, error) {
+	if blockTime <= 0 {
+		return 0, fmt.Errorf("measured average block time %s is not positive", blockTime)
+	}
+	return int64(math.Ceil(float64(remaining)/float64(blockTime))) + 1, nil
+}
@@ -0,0 +1,34 @@
+package junctiontest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlocksFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		remaining time.Duration
+		blockTime time.Duration
+		want      int64
+		wantErr   bool
+	}{
+		{"exact multiple", 60 * time.Second, 5 * time.Second, 13, false},
+		{"rounds up a partial block", 61 * time.Second, 5 * time.Second, 14, false},
+		{"sub-second blocks", 10 * time.Second, 400 * time.Millisecond, 26, false},
+		{"less than one block", time.Second, 5 * time.Second, 2, false},
+		{"zero block time", time.Minute, 0, 0, true},
+		{"negative block time", time.Minute, -time.Second, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := blocksFor(tt.remaining, tt.blockTime)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("blocksFor(%s, %s) error = %v, wantErr %v", tt.remaining, tt.blockTime, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("blocksFor(%s, %s) = %d, want %d", tt.remaining, tt.blockTime, got, tt.want)
+			}
+		})
+	}
+}
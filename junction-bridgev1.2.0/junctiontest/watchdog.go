@@ -0,0 +1,78 @@
+package junctiontest
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ChainWatchdog watches the background junctiond process and, when it exits
+// without being stopped, either restarts it (restart_on_crash) or records the
+// crash so long waits can abort instead of counting down against a dead
+// chain.
+type ChainWatchdog struct {
+	cfg      *ChainConfig
+	mu       sync.Mutex
+	err      error
+	restarts int
+}
+
+// watchdog is the watchdog for the chain started by StartChainBackground, if
+// any.
+var watchdog *ChainWatchdog
+
+func startWatchdog(cfg *ChainConfig) *ChainWatchdog {
+	w := &ChainWatchdog{cfg: cfg}
+	go w.run()
+	return w
+}
+
+func (w *ChainWatchdog) run() {
+	for {
+		done, ok := Processes.Done("junctiond")
+		if !ok {
+			return
+		}
+		<-done
+
+		// Stopped on purpose via the process registry
+		if !Processes.Has("junctiond") {
+			return
+		}
+		Processes.Remove("junctiond")
+
+		if !w.cfg.RestartOnCrash || w.restarts >= w.cfg.MaxRestarts {
+			w.fail(fmt.Errorf("%w: junctiond exited unexpectedly (see %s)", ErrChainNotReady, ChainLogPath(w.cfg)))
+			return
+		}
+
+		w.restarts++
+		fmt.Fprintf(os.Stderr, "Warning: junctiond exited unexpectedly, restarting (%d/%d)...\n", w.restarts, w.cfg.MaxRestarts)
+		if err := startChainProcess(w.cfg); err != nil {
+			w.fail(fmt.Errorf("%w: junctiond crashed and could not be restarted: %v", ErrChainNotReady, err))
+			return
+		}
+	}
+}
+
+func (w *ChainWatchdog) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+}
+
+// Err returns the crash error once the chain has died for good.
+func (w *ChainWatchdog) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// checkChainAlive returns an error if the watched background chain has
+// crashed. It is a no-op when no chain is being watched.
+func checkChainAlive() error {
+	if watchdog == nil {
+		return nil
+	}
+	return watchdog.Err()
+}
@@ -0,0 +1,67 @@
+package junctiontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ProposalWebhookPayload is the JSON body NotifyProposalWebhook posts when a
+// proposal reaches a final status.
+type ProposalWebhookPayload struct {
+	ChainID    string `json:"chain_id"`
+	ProposalID string `json:"proposal_id"`
+	Status     string `json:"status"`
+	Tally      struct {
+		Yes        string `json:"yes"`
+		No         string `json:"no"`
+		Abstain    string `json:"abstain"`
+		NoWithVeto string `json:"no_with_veto"`
+	} `json:"tally"`
+}
+
+// NewProposalWebhookPayload builds the webhook payload for a finished
+// proposal.
+func NewProposalWebhookPayload(chainID string, proposal *ProposalInfo) ProposalWebhookPayload {
+	payload := ProposalWebhookPayload{ChainID: chainID, ProposalID: proposal.ID, Status: proposal.Status}
+	payload.Tally.Yes = proposal.FinalTallyResult.YesCount
+	payload.Tally.No = proposal.FinalTallyResult.NoCount
+	payload.Tally.Abstain = proposal.FinalTallyResult.AbstainCount
+	payload.Tally.NoWithVeto = proposal.FinalTallyResult.NoWithVetoCount
+	return payload
+}
+
+// NotifyProposalWebhook posts payload to cfg.WebhookURL, retrying up to
+// cfg.WebhookRetries more times with a growing pause when the request fails
+// or the receiver answers with a non-2xx status. Each attempt is bounded by
+// cfg.WebhookTimeout. It does nothing if no URL is configured.
+func NotifyProposalWebhook(cfg *ChainConfig, payload ProposalWebhookPayload) error {
+	if cfg.WebhookURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error marshaling webhook payload: %v", err)
+	}
+
+	client := &http.Client{Timeout: cfg.WebhookTimeout}
+	var lastErr error
+	for attempt := 0; attempt <= cfg.WebhookRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 2 * time.Second)
+		}
+		resp, err := client.Post(cfg.WebhookURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return fmt.Errorf("error notifying webhook after %d attempts: %v", cfg.WebhookRetries+1, lastErr)
+}
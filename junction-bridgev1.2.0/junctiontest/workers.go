@@ -0,0 +1,175 @@
+package junctiontest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BridgeParams are the evmbridge module parameters set by NewBridgeProposal.
+type BridgeParams struct {
+	BridgeWorkers         []string `json:"bridge_workers"`
+	BridgeContractAddress string   `json:"bridge_contract_address"`
+}
+
+// QueryBridgeParams returns the evmbridge parameters currently active on
+// chain.
+func QueryBridgeParams(cfg *ChainConfig) (*BridgeParams, error) {
+	out, err := JunctiondCommand(cfg, "query", "evmbridge", "params", "--output", "json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("error querying evmbridge params: %v", err)
+	}
+
+	var response struct {
+		Params BridgeParams `json:"params"`
+	}
+	if err := json.Unmarshal(out, &response); err != nil {
+		return nil, fmt.Errorf("error parsing evmbridge params: %v", err)
+	}
+	return &response.Params, nil
+}
+
+// CheckBridgeParams compares the active evmbridge params with the expected
+// workers (in any order) and contract address, skipping an expectation that
+// is empty. Mismatches are returned in an error wrapping
+// ErrExpectationFailed.
+func CheckBridgeParams(params *BridgeParams, expectWorkers []string, expectContract string) error {
+	var mismatches []string
+	if len(expectWorkers) > 0 {
+		active := append([]string(nil), params.BridgeWorkers...)
+		expected := append([]string(nil), expectWorkers...)
+		sort.Strings(active)
+		sort.Strings(expected)
+		if strings.Join(active, ",") != strings.Join(expected, ",") {
+			mismatches = append(mismatches, fmt.Sprintf("active bridge workers %v, expected %v", params.BridgeWorkers, expectWorkers))
+		}
+	}
+	if expectContract != "" && !strings.EqualFold(params.BridgeContractAddress, expectContract) {
+		mismatches = append(mismatches, fmt.Sprintf("bridge contract is %s, expected %s", params.BridgeContractAddress, expectContract))
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: %s", ErrExpectationFailed, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// PassProposal submits proposal, votes yes with the configured key and waits
+// for it to finish, returning an error wrapping ErrProposalRejected if it
+// did not pass, or ErrValidatorSetChanged if the validator set moved in the
+// meantime. Cancelling ctx stops the waits and returns ctx's error.
+func PassProposal(ctx context.Context, cfg *ChainConfig, proposal Proposal, proposalPath string) (string, error) {
+	validatorsBefore, err := SnapshotValidatorSet(cfg.RPCEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	timer := newStepTimer(cfg)
+	if err := timer.next("submit"); err != nil {
+		return "", err
+	}
+	txResponse, err := SubmitProposal(cfg, proposal, proposalPath)
+	if err != nil {
+		return "", err
+	}
+	result, err := Gas.WaitForTxContext(ctx, cfg, "submit", txResponse.TxHash, 30*time.Second)
+	if err != nil {
+		return "", err
+	}
+	proposalID, err := ProposalIDFromTx(result)
+	if err != nil {
+		return "", err
+	}
+
+	if err := timer.next("vote"); err != nil {
+		return proposalID, err
+	}
+	fmt.Printf("🗳️  Voting yes on proposal %s...\n", proposalID)
+	voteResponse, err := Vote(cfg, proposalID, "yes")
+	if err != nil {
+		return proposalID, err
+	}
+	if _, err := Gas.WaitForTxContext(ctx, cfg, "vote", voteResponse.TxHash, 30*time.Second); err != nil {
+		return proposalID, err
+	}
+
+	if err := timer.next("voting_period"); err != nil {
+		return proposalID, err
+	}
+	info, err := FetchProposal(cfg.RestEndpoint, proposalID)
+	if err != nil {
+		return proposalID, fmt.Errorf("error fetching proposal %s: %v", proposalID, err)
+	}
+	if err := WaitForVotingPeriod(ctx, cfg, info); err != nil {
+		return proposalID, err
+	}
+
+	if err := timer.next("tally"); err != nil {
+		return proposalID, err
+	}
+	fmt.Printf("⏳ Waiting for proposal %s to finish...\n", proposalID)
+	info, err = WaitForProposalFinal(cfg.RestEndpoint, proposalID, 2*time.Minute)
+	if err != nil {
+		return proposalID, err
+	}
+	if err := timer.finish(); err != nil {
+		return proposalID, err
+	}
+	if err := CheckProposalOutcome(info); err != nil {
+		return proposalID, err
+	}
+	return proposalID, CheckValidatorSetUnchanged(cfg.RPCEndpoint, validatorsBefore)
+}
+
+// ScenarioBridgeWorkerRotation passes a proposal setting an initial worker
+// set, then a second one replacing part of it, and checks the chain ends up
+// with exactly the second set.
+func ScenarioBridgeWorkerRotation(cfg *ChainConfig) error {
+	if err := SetupChain(cfg); err != nil {
+		return err
+	}
+	if err := StartChainBackground(cfg); err != nil {
+		return err
+	}
+
+	if err := EnsureKey(cfg, "rotation-worker"); err != nil {
+		return err
+	}
+	validatorAddr, err := KeyAddress(cfg, cfg.KeyName)
+	if err != nil {
+		return fmt.Errorf("error looking up %s address: %v", cfg.KeyName, err)
+	}
+	rotatedAddr, err := KeyAddress(cfg, "rotation-worker")
+	if err != nil {
+		return fmt.Errorf("error looking up rotation-worker address: %v", err)
+	}
+
+	initial := NewBridgeProposal("", cfg.Expedited)
+	initialWorkers := append(initial.Messages[0].Params.BridgeWorkers, validatorAddr)
+	initial.Messages[0].Params.BridgeWorkers = initialWorkers
+	if _, err := PassProposal(context.Background(), cfg, initial, filepath.Join(cfg.Home(), "rotation_initial.json")); err != nil {
+		return fmt.Errorf("initial worker proposal: %w", err)
+	}
+	fmt.Printf("✅ Initial workers set: %v\n", initialWorkers)
+
+	// Keep the validator, replace the original worker
+	rotated := NewBridgeProposal("", cfg.Expedited)
+	rotatedWorkers := []string{validatorAddr, rotatedAddr}
+	rotated.Messages[0].Params.BridgeWorkers = rotatedWorkers
+	if _, err := PassProposal(context.Background(), cfg, rotated, filepath.Join(cfg.Home(), "rotation_rotated.json")); err != nil {
+		return fmt.Errorf("rotation proposal: %w", err)
+	}
+
+	params, err := QueryBridgeParams(cfg)
+	if err != nil {
+		return err
+	}
+	if err := CheckBridgeParams(params, rotatedWorkers, ""); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Only the rotated workers are active: %v\n", params.BridgeWorkers)
+	return nil
+}
@@ -1,80 +1,56 @@
 package main
 
 import (
-	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"os/signal"
+	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"junction-bridge/junctiontest"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-type Config struct {
-	Moniker          string `mapstructure:"moniker"`
-	ChainID          string `mapstructure:"chain_id"`
-	Denom            string `mapstructure:"denom"`
-	KeyName          string `mapstructure:"key_name"`
-	Amount           string `mapstructure:"amount"`
-	ValidatorStake   string `mapstructure:"validator_stake"`
-	JunctiondPath    string `mapstructure:"junctiond_path"`
-	HomeDir          string `mapstructure:"home_dir"`
-	MinimumGasPrices string `mapstructure:"minimum_gas_prices"`
-	RestEndpoint     string `mapstructure:"rest_endpoint"`
-}
-
-type ProposalMessage struct {
-	Type      string `json:"@type"`
-	Authority string `json:"authority"`
-	Params    struct {
-		BridgeWorkers         []string `json:"bridge_workers"`
-		BridgeContractAddress string   `json:"bridge_contract_address"`
-	} `json:"params"`
+// RunReport records the environment a run was produced with so results from
+// different checkouts can be compared.
+type RunReport struct {
+	Version          string `json:"version"`
+	Commit           string `json:"commit"`
+	BuildDate        string `json:"build_date"`
+	GoVersion        string `json:"go_version"`
+	JunctiondVersion string `json:"junctiond_version"`
+	// JunctiondVersionLong and GenesisSHA256 tell whether two runs really
+	// had the same inputs
+	JunctiondVersionLong string `json:"junctiond_version_long"`
+	GenesisSHA256        string `json:"genesis_sha256,omitempty"`
+	ChainID              string `json:"chain_id"`
+	StartedAt            string `json:"started_at"`
+	FinishedAt           string `json:"finished_at"`
 }
 
-type Proposal struct {
-	Messages  []ProposalMessage `json:"messages"`
-	Metadata  string            `json:"metadata"`
-	Deposit   string            `json:"deposit"`
-	Title     string            `json:"title"`
-	Summary   string            `json:"summary"`
-	Expedited bool              `json:"expedited"`
-}
+// Build metadata, set at build time via -ldflags "-X main.version=..."
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
 
-type ProposalResponse struct {
-	Proposals []struct {
-		ID               string `json:"id"`
-		Status           string `json:"status"`
-		VotingStartTime  string `json:"voting_start_time"`
-		VotingEndTime    string `json:"voting_end_time"`
-		FinalTallyResult struct {
-			YesCount        string `json:"yes_count"`
-			AbstainCount    string `json:"abstain_count"`
-			NoCount         string `json:"no_count"`
-			NoWithVetoCount string `json:"no_with_veto_count"`
-		} `json:"final_tally_result"`
-	} `json:"proposals"`
-}
+var config junctiontest.ChainConfig
 
-type GenesisConfig struct {
-	AppState struct {
-		Gov struct {
-			Params struct {
-				MaxDepositPeriod      string `json:"max_deposit_period"`
-				VotingPeriod          string `json:"voting_period"`
-				ExpeditedVotingPeriod string `json:"expedited_voting_period"`
-			} `json:"params"`
-		} `json:"gov"`
-	} `json:"app_state"`
-}
+// quiet suppresses all non-error output when set via --quiet.
+var quiet bool
 
-var config Config
+// originalStdout is the process stdout before --quiet or --print-proposal-id
+// redirect os.Stdout.
+var originalStdout = os.Stdout
 
 var rootCmd = &cobra.Command{
 	Use:   "junction-bridge",
@@ -82,6 +58,13 @@ var rootCmd = &cobra.Command{
 	Long:  "A tool for setting up and managing Junction blockchain nodes for bridge testing",
 }
 
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Long:  "Print the tool version, build metadata, Go version and detected junctiond version",
+	Run:   runVersion,
+}
+
 var initCmd = &cobra.Command{
 	Use:   "init-node",
 	Short: "Initialize and start a Junction node",
@@ -104,6 +87,21 @@ var voteCmd = &cobra.Command{
 	Run:   runVote,
 }
 
+var gasReportCmd = &cobra.Command{
+	Use:   "gas-report",
+	Short: "Show gas usage per transaction type",
+	Long:  "Show min/max/avg gas used by governance transactions recorded in gas_profile.json",
+	Run:   runGasReport,
+}
+
+var simulateProposalCmd = &cobra.Command{
+	Use:   "simulate-proposal [proposal-file]",
+	Short: "Dry-run a proposal tx and estimate its gas",
+	Long:  "Simulate submitting a proposal file without broadcasting it, reporting the estimated gas or why the tx would fail",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSimulateProposal,
+}
+
 var monitorCmd = &cobra.Command{
 	Use:   "monitor-proposals",
 	Short: "Monitor proposal status",
@@ -123,12 +121,97 @@ func init() {
 	viper.SetDefault("chain_id", "junction")
 	viper.SetDefault("denom", "uamf")
 	viper.SetDefault("key_name", "test1")
+	viper.SetDefault("key_mnemonic", "")
 	viper.SetDefault("amount", "100000000000uamf")
 	viper.SetDefault("validator_stake", "10000000000uamf")
+	viper.SetDefault("extra_accounts", []string{})
+	viper.SetDefault("key_workers", 4)
+	viper.SetDefault("genesis_file", "")
+	viper.SetDefault("skip_genesis_patches", false)
+	viper.SetDefault("genesis_inflation", "")
+	viper.SetDefault("genesis_inflation_min", "")
+	viper.SetDefault("genesis_inflation_max", "")
+	viper.SetDefault("genesis_inflation_rate_change", "")
+	viper.SetDefault("genesis_goal_bonded", "")
 	viper.SetDefault("junctiond_path", "./build/junctiond")
+	viper.SetDefault("junctiond_sha256", "")
+	viper.SetDefault("runner", "local")
+	viper.SetDefault("gov_version", "auto")
+	viper.SetDefault("proposal_format", "v1")
+	viper.SetDefault("docker_image", "")
 	viper.SetDefault("home_dir", "$HOME/.junction")
+	viper.SetDefault("skip_cleanup", false)
+	viper.SetDefault("skip_cleanup_force", false)
+	viper.SetDefault("snapshot_dir", "$HOME/.junction-snapshots")
+	viper.SetDefault("output_dir", ".")
+	viper.SetDefault("output_file_mode", "0644")
 	viper.SetDefault("minimum_gas_prices", "0.00025uamf")
+	viper.SetDefault("app_toml_overrides", "")
+	viper.SetDefault("config_toml_overrides", "")
+	viper.SetDefault("cors_origins", []string{})
+	viper.SetDefault("dns_seeds", []string{})
 	viper.SetDefault("rest_endpoint", "http://localhost:1317")
+	viper.SetDefault("rpc_endpoint", "http://localhost:26657")
+	viper.SetDefault("grpc_endpoint", "http://localhost:9090")
+	viper.SetDefault("explorer_url", "")
+	viper.SetDefault("http_addr", "")
+	viper.SetDefault("metrics_addr", "")
+	viper.SetDefault("webhook_url", "")
+	viper.SetDefault("tui", false)
+	viper.SetDefault("single_process", false)
+	viper.SetDefault("follow_logs", false)
+	viper.SetDefault("start_flags", []string{})
+	viper.SetDefault("pruning", "")
+	viper.SetDefault("skip_invariants", false)
+	viper.SetDefault("log_level", "")
+	viper.SetDefault("mode", "local")
+	viper.SetDefault("test_chain_ids", junctiontest.DefaultTestChainIDs)
+	viper.SetDefault("allow_non_test_chain", false)
+	viper.SetDefault("spinner", "dots")
+	viper.SetDefault("spinner_interval", "100ms")
+	viper.SetDefault("simulate_first", false)
+	viper.SetDefault("sign_proposal", false)
+	viper.SetDefault("gas_mode", "auto")
+	viper.SetDefault("gas_adjustment", 1.5)
+	viper.SetDefault("gas_limit", 200000)
+	viper.SetDefault("fees", "")
+	viper.SetDefault("broadcast_mode", junctiontest.BroadcastModeSync)
+	viper.SetDefault("expedited", false)
+	viper.SetDefault("proposal_messages_file", "")
+	viper.SetDefault("proposal_authors", "")
+	viper.SetDefault("vote_option_context", "yes,no,abstain")
+	viper.SetDefault("expect_status", "")
+	viper.SetDefault("expect_tally_yes_min", 0)
+	viper.SetDefault("expect_bridge_workers", []string{})
+	viper.SetDefault("expect_bridge_contract", "")
+	viper.SetDefault("post_hook", "")
+	viper.SetDefault("metadata_template", "")
+	viper.SetDefault("ipfs_gateway", "https://ipfs.io/ipfs/")
+	viper.SetDefault("ipfs_gateways", []string{})
+	viper.SetDefault("ipfs_timeout", "10s")
+	viper.SetDefault("verify_ipfs", false)
+	viper.SetDefault("ipfs_cid", "")
+	viper.SetDefault("cid_cache_file", "ipfs_cid_cache.json")
+	viper.SetDefault("sync_timeout", "2m")
+	viper.SetDefault("ready_poll_initial", "500ms")
+	viper.SetDefault("ready_poll_max", "10s")
+	viper.SetDefault("confirmations", 1)
+	viper.SetDefault("wait_mode", "time")
+	viper.SetDefault("proposal_timeout", 0)
+	viper.SetDefault("webhook_timeout", "10s")
+	viper.SetDefault("webhook_retries", 3)
+	viper.SetDefault("restart_on_crash", false)
+	viper.SetDefault("keep_running", true)
+	viper.SetDefault("max_restarts", 3)
+	viper.SetDefault("max_memory_growth_kb_per_block", 100)
+	viper.SetDefault("endurance_duration", "30m")
+	viper.SetDefault("relayer_path", "hermes")
+	viper.SetDefault("relayer_home", "$HOME/.junction-relayer")
+	viper.SetDefault("relayer_mnemonic_file", "./relayer_mnemonic.txt")
+	viper.SetDefault("strict_timing", false)
+
+	// Allow environment variables (e.g. EXPLORER_URL) to override config values
+	viper.AutomaticEnv()
 
 	// Bind flags
 	initCmd.Flags().String("moniker", "junction-testing", "Moniker for the node")
@@ -140,13 +223,45 @@ func init() {
 	initCmd.Flags().String("junctiond-path", "./build/junctiond", "Path to junctiond binary")
 	initCmd.Flags().String("home-dir", "$HOME/.junction", "Home directory")
 	initCmd.Flags().String("minimum-gas-prices", "0.00025uamf", "Minimum gas prices")
+	initCmd.Flags().Int64("fork-state", 0, "Instead of running the node, fork its state at this height and check both instances agree")
 
 	viper.BindPFlags(initCmd.Flags())
+	initCmd.Flags().StringSlice("dns-seeds", nil, "Comma-separated node-id@host:port p2p seeds, e.g. for DNS-based peer discovery")
+	bindFlag("dns_seeds", initCmd.Flags().Lookup("dns-seeds"))
+
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress all output except errors")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show extra detail, such as genesis changes")
+	bindFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	rootCmd.PersistentFlags().String("workdir", "", "Directory to run in, containing build/junctiond and config.yaml")
+	bindFlag("workdir", rootCmd.PersistentFlags().Lookup("workdir"))
+	rootCmd.PersistentFlags().Bool("strict-config", false, "Fail if live chain params drift from the config")
+	bindFlag("strict_config", rootCmd.PersistentFlags().Lookup("strict-config"))
+	rootCmd.PersistentFlags().String("node", "http://localhost:26657", "RPC endpoint of the node to query and send txs to (overrides rpc_endpoint)")
+	bindFlag("rpc_endpoint", rootCmd.PersistentFlags().Lookup("node"))
+	rootCmd.PersistentFlags().Bool("strict-timing", false, "Fail when a step exceeds its step_budget instead of warning")
+	bindFlag("strict_timing", rootCmd.PersistentFlags().Lookup("strict-timing"))
+	rootCmd.PersistentFlags().Int("proposal-timeout", 0, "Seconds to wait for the voting period, overriding the proposal's voting end time")
+	bindFlag("proposal_timeout", rootCmd.PersistentFlags().Lookup("proposal-timeout"))
+	rootCmd.PersistentFlags().Bool("ignore-version-pin", false, "Skip the pinned junctiond version check (for intentional upgrades)")
+	bindFlag("ignore_version_pin", rootCmd.PersistentFlags().Lookup("ignore-version-pin"))
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if quiet {
+			silenceStdout()
+		}
+	}
+
+	rootCmd.Version = version
+	rootCmd.SetVersionTemplate(fmt.Sprintf("junction-bridge %s (commit %s, built %s, %s)\n", version, commit, buildDate, runtime.Version()))
 
+	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(initCmd)
+	submitProposalCmd.Flags().Bool("print-proposal-id", false, "Print only the proposal ID to stdout; all other output goes to stderr")
 	rootCmd.AddCommand(submitProposalCmd)
+	voteCmd.Flags().String("keys", "", "Comma-separated keys to vote from one after another instead of key_name")
 	rootCmd.AddCommand(voteCmd)
 	rootCmd.AddCommand(monitorCmd)
+	rootCmd.AddCommand(gasReportCmd)
+	rootCmd.AddCommand(simulateProposalCmd)
 }
 
 func main() {
@@ -156,16 +271,68 @@ func main() {
 	}
 }
 
+// silenceStdout discards everything written to stdout, including the output of
+// junctiond subprocesses. Errors and warnings are written to stderr and are
+// unaffected.
+func silenceStdout() {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not enable quiet mode: %v\n", err)
+		return
+	}
+	os.Stdout = devNull
+}
+
+func runVersion(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	fmt.Printf("junction-bridge: %s\n", version)
+	fmt.Printf("Commit: %s\n", commit)
+	fmt.Printf("Build date: %s\n", buildDate)
+	fmt.Printf("Go version: %s\n", runtime.Version())
+	fmt.Printf("junctiond version: %s\n", junctiontest.DetectJunctiondVersion(&config))
+}
+
+func newRunReport() *RunReport {
+	report := &RunReport{
+		Version:              version,
+		Commit:               commit,
+		BuildDate:            buildDate,
+		GoVersion:            runtime.Version(),
+		JunctiondVersion:     junctiontest.DetectJunctiondVersion(&config),
+		JunctiondVersionLong: junctiontest.DetectJunctiondVersionLong(&config),
+		ChainID:              config.ChainID,
+		StartedAt:            time.Now().Format(time.RFC3339),
+	}
+	// The genesis is only local when this machine runs the node
+	if genesisHash, err := junctiontest.GenesisHash(&config); err == nil {
+		report.GenesisSHA256 = genesisHash
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: genesis hash not recorded in run report: %v\n", err)
+	}
+	return report
+}
+
+func writeRunReport(report *RunReport) error {
+	report.FinishedAt = time.Now().Format(time.RFC3339)
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling run report: %v", err)
+	}
+
+	if err := junctiontest.WriteFileAtomic("run_report.json", data); err != nil {
+		return fmt.Errorf("error writing run report: %v", err)
+	}
+	return nil
+}
+
 func runInitNode(cmd *cobra.Command, args []string) {
 	// Load configuration
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			fmt.Printf("Error reading config file: %v\n", err)
-		}
-	}
+	loadConfig()
 
-	if err := viper.Unmarshal(&config); err != nil {
-		fmt.Printf("Error unmarshaling config: %v\n", err)
+	if config.Mode == junctiontest.ModeRemote {
+		fmt.Fprintln(os.Stderr, "Error: init-node sets up a local chain; unset MODE=remote to use it")
 		os.Exit(1)
 	}
 
@@ -174,280 +341,407 @@ func runInitNode(cmd *cobra.Command, args []string) {
 	fmt.Printf("Chain ID: %s\n", config.ChainID)
 	fmt.Printf("Denom: %s\n", config.Denom)
 
-	// Step 1: Remove existing junctiond directory
-	fmt.Println("\n📁 Removing existing junctiond directory...")
-	homeDir := os.ExpandEnv(config.HomeDir)
-	if err := os.RemoveAll(homeDir); err != nil {
-		fmt.Printf("Warning: Could not remove existing directory: %v\n", err)
+	startStatusServer()
+	startMetricsServer()
+	updateState(func(state *TestingState) {
+		*state = TestingState{ChainID: config.ChainID, Phase: phaseInitializing}
+	})
+
+	if err := junctiontest.SetupChain(&config); err != nil {
+		exitWithError("Error", err)
+	}
+	if extraAccounts, _ := junctiontest.ParseExtraAccounts(config.ExtraAccounts); len(extraAccounts) > 0 {
+		updateState(func(state *TestingState) {
+			for _, account := range extraAccounts {
+				state.ExtraAccounts = append(state.ExtraAccounts, account.Name)
+			}
+		})
 	}
 
-	// Step 2: Initialize the junctiond node
-	fmt.Println("\n🔧 Initializing junctiond node...")
-	initCmd := exec.Command(config.JunctiondPath, "init", config.Moniker, "--default-denom", config.Denom, "--chain-id", config.ChainID)
-	if err := runCommand(initCmd); err != nil {
-		fmt.Printf("Error initializing node: %v\n", err)
-		os.Exit(1)
+	if forkHeight, _ := cmd.Flags().GetInt64("fork-state"); forkHeight > 0 {
+		runForkState(forkHeight)
+		return
 	}
 
-	// Step 3: Generate keys (or use existing)
-	fmt.Println("\n🔑 Generating keys...")
+	// Stop exactly our node if this process is told to stop; the PID file
+	// tells RunChain the exit was deliberate
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		if err := junctiontest.StopChainFromPIDFile(&config); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		stopStatusServer()
+	}()
 
-	// First check if key already exists
-	checkKeyCmd := exec.Command(config.JunctiondPath, "keys", "show", config.KeyName, "--keyring-backend", "os")
-	err := checkKeyCmd.Run()
+	// Step 10: Start the node
+	fmt.Println("\n🚀 Starting junctiond node...")
+	fmt.Println("Node will start with minimum gas prices:", config.MinimumGasPrices)
 
+	updateState(func(state *TestingState) { state.Phase = phaseChainRunning })
+	err := junctiontest.RunChain(&config)
+	updateState(func(state *TestingState) { state.Phase = phaseChainStopped })
+	stopStatusServer()
 	if err != nil {
-		// Key doesn't exist, create it
-		fmt.Printf("🔑 Creating new key: %s\n", config.KeyName)
-		keyCmd := exec.Command(config.JunctiondPath, "keys", "add", config.KeyName, "--keyring-backend", "os")
-		if err := runCommand(keyCmd); err != nil {
-			fmt.Printf("Error generating keys: %v\n", err)
-			os.Exit(1)
-		}
-	} else {
-		// Key already exists, use it
-		fmt.Printf("✅ Using existing key: %s\n", config.KeyName)
+		exitWithError("Error", err)
 	}
+}
 
-	// Step 4: Add genesis account
-	fmt.Println("\n💰 Adding genesis account...")
-	genesisAccountCmd := exec.Command(config.JunctiondPath, "genesis", "add-genesis-account", config.KeyName, config.Amount, "--keyring-backend", "os")
-	if err := runCommand(genesisAccountCmd); err != nil {
-		fmt.Printf("Error adding genesis account: %v\n", err)
+func loadConfig() {
+	if err := enterWorkdir(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			fmt.Fprintf(os.Stderr, "Error reading config file: %v\n", err)
+		}
+	}
 
-	// Step 5: Stake validator account
-	fmt.Println("\n🏛️ Staking validator account...")
-	gentxCmd := exec.Command(config.JunctiondPath, "genesis", "gentx", config.KeyName, config.ValidatorStake, "--keyring-backend", "os", "--gas-prices", "0.0025uamf", "--chain-id", config.ChainID)
-	if err := runCommand(gentxCmd); err != nil {
-		fmt.Printf("Error creating gentx: %v\n", err)
+	if err := viper.Unmarshal(&config); err != nil {
+		fmt.Fprintf(os.Stderr, "Error unmarshaling config: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Step 6: Collect gentx files
-	fmt.Println("\n📋 Collecting gentx files...")
-	collectGentxCmd := exec.Command(config.JunctiondPath, "genesis", "collect-gentxs")
-	if err := runCommand(collectGentxCmd); err != nil {
-		fmt.Printf("Error collecting gentx files: %v\n", err)
+	mode, err := strconv.ParseUint(config.OutputFileMode, 8, 32)
+	if err != nil || mode > 0777 {
+		fmt.Fprintf(os.Stderr, "Error: invalid output_file_mode %q (expected octal permissions such as 0644)\n", config.OutputFileMode)
 		os.Exit(1)
 	}
-
-	// Step 7: Modify genesis file
-	fmt.Println("\n⚙️ Modifying genesis file...")
-	if err := modifyGenesisFile(homeDir); err != nil {
-		fmt.Printf("Error modifying genesis file: %v\n", err)
+	junctiontest.OutputFileMode = os.FileMode(mode)
+	switch config.ProposalFormat {
+	case junctiontest.ProposalFormatV1:
+	case junctiontest.ProposalFormatLegacy:
+		if config.SignProposal {
+			fmt.Fprintln(os.Stderr, "Error: sign_proposal needs proposal_format v1, since legacy proposals carry the metadata URI in their description")
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid proposal_format %q (expected v1 or legacy)\n", config.ProposalFormat)
 		os.Exit(1)
 	}
-
-	// Step 8: Modify app.toml file
-	fmt.Println("\n🔧 Modifying app.toml file...")
-	if err := modifyAppTomlFile(homeDir); err != nil {
-		fmt.Printf("Error modifying app.toml file: %v\n", err)
+	if err := junctiontest.ValidateBroadcastMode(config.BroadcastMode); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Step 9: Start the node
-	fmt.Println("\n🚀 Starting junctiond node...")
-	fmt.Println("Node will start with minimum gas prices:", config.MinimumGasPrices)
-
-	startCmd := exec.Command(config.JunctiondPath, "start", "--minimum-gas-prices", config.MinimumGasPrices)
-	startCmd.Stdout = os.Stdout
-	startCmd.Stderr = os.Stderr
-
-	if err := startCmd.Run(); err != nil {
-		fmt.Printf("Error starting node: %v\n", err)
+	if config.ReadyPollInitial <= 0 || config.ReadyPollMax < config.ReadyPollInitial {
+		fmt.Fprintf(os.Stderr, "Error: ready_poll_initial (%s) must be positive and no larger than ready_poll_max (%s)\n", config.ReadyPollInitial, config.ReadyPollMax)
+		os.Exit(1)
+	}
+	switch config.Mode {
+	case junctiontest.ModeLocal:
+	case junctiontest.ModeRemote:
+		if config.SingleProcess {
+			fmt.Fprintln(os.Stderr, "Error: single_process starts a local chain and cannot be used with mode remote")
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: invalid mode %q (expected local or remote)\n", config.Mode)
+		os.Exit(1)
+	}
+	if _, err := junctiontest.StartArgs(&config); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if config.Workdir != "" && config.Runner != junctiontest.RunnerDocker {
+		if _, err := os.Stat(config.JunctiondPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: workdir %s has no junctiond at %s: %v\n", config.Workdir, config.JunctiondPath, err)
+			os.Exit(1)
+		}
+	}
+	loadExitCodes()
 }
 
-func runCommand(cmd *exec.Cmd) error {
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// enterWorkdir changes into WORKDIR (or --workdir), if set, so relative paths
+// such as ./build/junctiond, config.yaml and the state files resolve there
+// no matter where the tool is started from.
+func enterWorkdir() error {
+	workdir := os.ExpandEnv(viper.GetString("workdir"))
+	if workdir == "" {
+		return nil
+	}
+	if err := os.Chdir(workdir); err != nil {
+		return fmt.Errorf("cannot use workdir: %v", err)
+	}
+	// Viper resolved the "." config path when it was added, so point it at
+	// the workdir's config.yaml explicitly
+	if _, err := os.Stat("config.yaml"); err == nil {
+		viper.SetConfigFile("config.yaml")
+	}
+	return nil
 }
 
-func modifyGenesisFile(homeDir string) error {
-	genesisFile := filepath.Join(homeDir, "config", "genesis.json")
+// runForkState runs the node in the background, forks it at forkHeight and
+// exits with the result.
+func runForkState(forkHeight int64) {
+	fmt.Printf("\n🍴 Testing state fork at height %d...\n", forkHeight)
+	if err := junctiontest.StartChainBackground(&config); err != nil {
+		junctiontest.Processes.StopAll()
+		exitWithError("Error", err)
+	}
 
-	// Read the genesis file
-	data, err := os.ReadFile(genesisFile)
+	err := junctiontest.ForkChainState(&config, forkHeight)
+	junctiontest.Processes.StopAll()
 	if err != nil {
-		return fmt.Errorf("error reading genesis file: %v", err)
+		exitWithError("Error forking chain state", err)
 	}
+}
 
-	// Parse JSON
-	var genesis map[string]interface{}
-	if err := json.Unmarshal(data, &genesis); err != nil {
-		return fmt.Errorf("error parsing genesis file: %v", err)
+// checkConfigDrift prints any differences between the live chain params and
+// the config, exiting if --strict-config is set.
+func checkConfigDrift() {
+	drift, err := junctiontest.DetectConfigDrift(config.RPCEndpoint, &config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not check config drift: %v\n", err)
+		return
 	}
-
-	// Navigate to app_state.gov.params and update values
-	appState, ok := genesis["app_state"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("app_state not found in genesis file")
+	if len(drift) == 0 {
+		return
 	}
 
-	gov, ok := appState["gov"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("gov not found in app_state")
+	fmt.Fprintln(os.Stderr, "⚠️  Chain params differ from the config:")
+	for _, item := range drift {
+		fmt.Fprintf(os.Stderr, "   %s: expected %s, chain has %s\n", item.Parameter, item.Expected, item.Actual)
 	}
-
-	params, ok := gov["params"].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("params not found in gov")
+	if config.StrictConfig {
+		exitWithError("Error", fmt.Errorf("%w: %d parameter(s) differ", junctiontest.ErrConfigDrift, len(drift)))
 	}
+}
 
-	// Update the parameters
-	params["max_deposit_period"] = "600s"
-	params["voting_period"] = "660s"
-	params["expedited_voting_period"] = "300s"
+func runSubmitProposal(cmd *cobra.Command, args []string) {
+	// Load configuration
+	loadConfig()
+	startGasProfile()
 
-	// Write back to file
-	updatedData, err := json.MarshalIndent(genesis, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshaling updated genesis: %v", err)
+	if config.TUI {
+		runSubmitTUI()
+		return
 	}
 
-	if err := os.WriteFile(genesisFile, updatedData, 0644); err != nil {
-		return fmt.Errorf("error writing updated genesis file: %v", err)
+	// With --print-proposal-id, stdout carries only the proposal ID and
+	// everything else, including subprocess output, goes to stderr
+	var idOut *os.File
+	if printID, _ := cmd.Flags().GetBool("print-proposal-id"); printID {
+		idOut = originalStdout
+		if !quiet {
+			os.Stdout = os.Stderr
+		}
 	}
 
-	fmt.Println("✅ Genesis file updated with new voting and deposit periods")
-	return nil
-}
-
-func modifyAppTomlFile(homeDir string) error {
-	appTomlFile := filepath.Join(homeDir, "config", "app.toml")
-
-	// Read the app.toml file
-	data, err := os.ReadFile(appTomlFile)
-	if err != nil {
-		return fmt.Errorf("error reading app.toml file: %v", err)
+	fmt.Println("🗳️  Starting Governance Proposal Submission...")
+	startMetricsServer()
+	if config.SingleProcess {
+		startSingleProcessChain()
 	}
+	report := newRunReport()
 
-	content := string(data)
+	// Make sure the binary is present and the node is up and caught up
+	// before doing anything
+	if err := junctiontest.CheckJunctiond(&config); err != nil {
+		exitWithError("Error", err)
+	}
+	if err := junctiontest.CheckTestChain(&config); err != nil {
+		exitWithError("Error", err)
+	}
+	if config.Mode == junctiontest.ModeRemote {
+		checkRemoteNode()
+	}
+	if err := junctiontest.WaitForSync(config.RPCEndpoint, config.SyncTimeout, config.ReadyBackoff()); err != nil {
+		exitWithError("Error", err)
+	}
 
-	// Apply modifications
-	content = strings.ReplaceAll(content, `minimum-gas-prices = ""`, `minimum-gas-prices = "0.00025uamf"`)
-	content = strings.ReplaceAll(content, `enable = false`, `enable = true`)
-	content = strings.ReplaceAll(content, `swagger = false`, `swagger = true`)
+	checkConfigDrift()
 
-	// Write back to file
-	if err := os.WriteFile(appTomlFile, []byte(content), 0644); err != nil {
-		return fmt.Errorf("error writing updated app.toml file: %v", err)
+	// Fail up front if the proposer cannot pay the deposit and fees
+	if err := junctiontest.CheckProposerBalance(&config, junctiontest.DefaultProposalDeposit); err != nil {
+		exitWithError("Error", err)
 	}
 
-	fmt.Println("✅ App.toml file updated with new minimum gas prices")
-	return nil
-}
+	// Snapshot the validator set so monitor-proposals can check it did not
+	// change while the proposal was in flight
+	validatorsBefore, err := junctiontest.SnapshotValidatorSet(config.RPCEndpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not snapshot validator set: %v\n", err)
+	}
 
-func runSubmitProposal(cmd *cobra.Command, args []string) {
-	// Load configuration
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			fmt.Printf("Error reading config file: %v\n", err)
+	// Load custom messages before prompting so a bad file fails fast
+	var rawMessages []json.RawMessage
+	if config.ProposalMessagesFile != "" {
+		rawMessages, err = junctiontest.ReadProposalMessagesFile(config.ProposalMessagesFile)
+		if err != nil {
+			exitWithError("Error", err)
 		}
+		fmt.Printf("📄 Using %d message(s) from %s\n", len(rawMessages), config.ProposalMessagesFile)
 	}
 
-	if err := viper.Unmarshal(&config); err != nil {
-		fmt.Printf("Error unmarshaling config: %v\n", err)
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating output directory %s: %v\n", config.OutputDir, err)
 		os.Exit(1)
 	}
-
-	fmt.Println("🗳️  Starting Governance Proposal Submission...")
+	metadataPath := metadataFilePath()
+	proposalPath := proposalFilePath()
+
+	// The metadata (draft or template) is the single source of the
+	// proposal's title and summary; they are copied onto the proposal below
+	proposal := junctiontest.NewBridgeProposal("", config.Expedited)
+	proposal.RawMessages = rawMessages
+	if removed := junctiontest.NormalizeBridgeWorkers(&proposal); len(removed) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: removed duplicate bridge workers: %s\n", strings.Join(removed, ", "))
+	}
 
 	// Step 1: Create metadata.json from draft template
-	fmt.Println("\n📝 Creating metadata.json from draft template...")
+	fmt.Printf("\n📝 Creating %s from draft template...\n", metadataPath)
 
-	// Read the draft metadata template
-	draftMetadata, err := os.ReadFile("draft_metadata.json")
+	// Render the metadata from the draft or METADATA_TEMPLATE
+	metadata, err := junctiontest.BuildMetadata(&config, &proposal)
 	if err != nil {
-		fmt.Printf("Error reading draft_metadata.json: %v\n", err)
-		fmt.Println("Please ensure draft_metadata.json exists in the current directory")
-		os.Exit(1)
+		exitWithError("Error", err)
+	}
+
+	// The signature covers everything in the proposal except its metadata
+	// field, so it can be made before the CID is known
+	if config.SignProposal {
+		if err := junctiontest.WriteProposalFileFormat(proposalPath, proposal, config.ProposalFormat); err != nil {
+			exitWithError("Error", err)
+		}
+		signature, pubKey, err := junctiontest.SignProposalHash(&config, config.KeyName, proposalPath)
+		if err != nil {
+			exitWithError("Error", err)
+		}
+		metadata.AuthorAddress, err = junctiontest.KeyAddress(&config, config.KeyName)
+		if err != nil {
+			exitWithError("Error", err)
+		}
+		metadata.AuthorPubKey = pubKey
+		metadata.AuthorSignature = signature
+		fmt.Printf("✍️  Signed the proposal hash with %s\n", config.KeyName)
 	}
 
 	// Write metadata.json
-	if err := os.WriteFile("metadata.json", draftMetadata, 0644); err != nil {
-		fmt.Printf("Error creating metadata.json: %v\n", err)
+	if err := junctiontest.WriteMetadataFile(metadataPath, metadata); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", metadataPath, err)
 		os.Exit(1)
 	}
 
-	fmt.Println("✅ metadata.json created successfully")
-	fmt.Println("\n📤 Next steps:")
-	fmt.Println("1. Upload metadata.json to IPFS")
-	fmt.Println("2. Copy the IPFS CID (hash)")
-	fmt.Println("3. Paste the CID below")
-	fmt.Println("\nExample IPFS upload commands:")
-	fmt.Println("  # Using ipfs CLI:")
-	fmt.Println("  ipfs add metadata.json")
-	fmt.Println("  # Or using web interface at https://ipfs.io/")
-	fmt.Println("")
-	fmt.Print("Enter IPFS CID: ")
-	reader := bufio.NewReader(os.Stdin)
-	ipfsCID, err := reader.ReadString('\n')
-	if err != nil {
-		fmt.Printf("Error reading input: %v\n", err)
-		os.Exit(1)
+	fmt.Printf("✅ %s created successfully\n", metadataPath)
+
+	// Unchanged metadata was already uploaded, so its CID is reused
+	ipfsCID, cached := cachedCID(metadataPath)
+	if cached {
+		fmt.Printf("♻️  %s is unchanged since it was uploaded as %s, reusing that CID\n", metadataPath, ipfsCID)
+	} else {
+		fmt.Println("\n📤 Next steps:")
+		fmt.Printf("1. Upload %s to IPFS\n", metadataPath)
+		fmt.Println("2. Copy the IPFS CID (hash)")
+		fmt.Println("3. Paste the CID below")
+		fmt.Println("\nExample IPFS upload commands:")
+		fmt.Println("  # Using ipfs CLI:")
+		fmt.Printf("  ipfs add %s\n", metadataPath)
+		fmt.Println("  # Or using web interface at https://ipfs.io/")
+		fmt.Println("")
+		ipfsCID, err = promptString(fmt.Sprintf("Enter IPFS CID of %s", metadataPath), config.IPFSCID, "IPFS_CID")
+		if err != nil {
+			exitWithError("Error", err)
+		}
+	}
+
+	// A CIDv0 can be recomputed from the file, catching copy-paste errors
+	if err := junctiontest.VerifyLocalCID(metadataPath, ipfsCID); errors.Is(err, junctiontest.ErrCIDNotVerifiable) {
+		fmt.Fprintf(os.Stderr, "Warning: not checking the CID against %s: %v\n", metadataPath, err)
+	} else if err != nil {
+		exitWithError("Error", err)
+	} else {
+		fmt.Printf("✅ CID matches %s\n", metadataPath)
+	}
+	if config.VerifyIPFS {
+		fmt.Println("\n🌐 Checking the metadata is retrievable from IPFS...")
+		gateway, err := junctiontest.VerifyIPFSMetadata(config.IPFSGatewayList(), ipfsCID, metadataPath, config.IPFSTimeout)
+		if err != nil {
+			exitWithError("Error", err)
+		}
+		fmt.Printf("✅ %s served by %s matches %s\n", ipfsCID, gateway, metadataPath)
+	}
+	if !cached {
+		cacheCID(metadataPath, ipfsCID)
 	}
-	ipfsCID = strings.TrimSpace(ipfsCID)
 
 	// Step 2: Create proposal.json
-	fmt.Println("\n📝 Creating proposal.json...")
-	proposal := Proposal{
-		Messages: []ProposalMessage{
-			{
-				Type:      "/junction.evmbridge.MsgUpdateParams",
-				Authority: "air10d07y265gmmuvt4z0w9aw880jnsr700jszsute",
-				Params: struct {
-					BridgeWorkers         []string `json:"bridge_workers"`
-					BridgeContractAddress string   `json:"bridge_contract_address"`
-				}{
-					BridgeWorkers:         []string{"air1h58eezgk5j4jwwpk3nxggx63gfuhnfcj78z5vj"},
-					BridgeContractAddress: "0xd47248E2f6C725Dd20C82893162aA545C345834e",
-				},
-			},
-		},
-		Metadata:  fmt.Sprintf("ipfs://%s", ipfsCID),
-		Deposit:   "51000000uamf",
-		Title:     "Update EVM Bridge Authorized Unlockers",
-		Summary:   "This proposal aims to update the EVM bridge authorized unlockers list and add new bridge contract addresses to enhance the bridge's security and functionality.",
-		Expedited: true,
-	}
-
-	proposalData, err := json.MarshalIndent(proposal, "", " ")
-	if err != nil {
-		fmt.Printf("Error marshaling proposal: %v\n", err)
+	fmt.Printf("\n📝 Creating %s...\n", proposalPath)
+	proposal.Metadata = fmt.Sprintf("ipfs://%s", ipfsCID)
+
+	if err := junctiontest.WriteProposalFileFormat(proposalPath, proposal, config.ProposalFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if err := os.WriteFile("proposal.json", proposalData, 0644); err != nil {
-		fmt.Printf("Error writing proposal.json: %v\n", err)
-		os.Exit(1)
+	fmt.Printf("✅ %s created successfully\n", proposalPath)
+
+	if config.SignProposal {
+		if err := junctiontest.VerifyProposalIntegrity(&config, metadata, proposalPath); err != nil {
+			exitWithError("Error", err)
+		}
+		fmt.Println("✅ Author signature matches the proposal")
 	}
 
-	fmt.Println("✅ proposal.json created successfully")
+	// From here on Ctrl+C cancels the in-flight waits instead of killing
+	// the process mid-step
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Step 3: Submit proposal to chain
 	fmt.Println("\n🚀 Submitting proposal to chain...")
-	submitCmd := exec.Command(
-		config.JunctiondPath,
-		"tx", "gov", "submit-proposal", "proposal.json",
-		"--from", config.KeyName,
-		"--chain-id", config.ChainID,
-		"--fees", "500uamf",
-		"--gas", "auto",
-		"--keyring-backend", "os",
-		"-y",
-	)
-
-	if err := runCommand(submitCmd); err != nil {
-		fmt.Printf("Error submitting proposal: %v\n", err)
-		os.Exit(1)
+	txResponse, err := junctiontest.SubmitProposalFile(&config, proposalPath)
+	if err != nil {
+		exitWithError("Error submitting proposal", err)
+	}
+
+	result, err := waitForTx(ctx, "submit", txResponse.TxHash)
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "\n🛑 Interrupted; proposal tx %s was broadcast but not confirmed\n", txResponse.TxHash)
+		os.Exit(130)
+	}
+	if err != nil {
+		exitWithError("Error confirming proposal", err)
+	}
+	proposalID, err := junctiontest.ProposalIDFromTx(result)
+	if err != nil {
+		exitWithError("Error", err)
+	}
+
+	fmt.Printf("✅ Proposal %s submitted successfully!\n", proposalID)
+	updateState(func(state *TestingState) {
+		state.Phase = phaseProposalSubmitted
+		state.ProposalID = proposalID
+		state.Outcome = ""
+	})
+	if idOut != nil {
+		fmt.Fprintln(idOut, proposalID)
+	}
+	if validatorsBefore != nil {
+		if err := junctiontest.WriteValidatorSnapshot(junctiontest.ValidatorSnapshotPath(&config, proposalID), validatorsBefore); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save validator set snapshot: %v\n", err)
+		}
+	}
+	// A remote chain's own gov params decide its voting period
+	switch {
+	case config.Mode == junctiontest.ModeRemote:
+	case config.Expedited:
+		fmt.Printf("⏰ Expedited proposal: voting period is %s\n", config.VotingPeriod())
+	default:
+		fmt.Printf("⏰ Voting period is %s (set EXPEDITED=true for the shorter expedited period)\n", config.VotingPeriod())
 	}
 
-	fmt.Println("✅ Proposal submitted successfully!")
+	if err := writeRunReport(report); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if config.SingleProcess {
+		finishSingleProcess(ctx, proposalID)
+		return
+	}
+	if config.Mode == junctiontest.ModeRemote {
+		finishRemote(ctx, proposalID)
+		return
+	}
 	fmt.Println("\n🎯 Next steps:")
 	fmt.Println("1. Wait for the deposit period to end")
 	fmt.Println("2. Use 'junction-bridge vote <proposal-id> <vote-option>' to vote")
@@ -456,83 +750,110 @@ func runSubmitProposal(cmd *cobra.Command, args []string) {
 
 func runVote(cmd *cobra.Command, args []string) {
 	// Load configuration
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			fmt.Printf("Error reading config file: %v\n", err)
-		}
-	}
-
-	if err := viper.Unmarshal(&config); err != nil {
-		fmt.Printf("Error unmarshaling config: %v\n", err)
-		os.Exit(1)
-	}
+	loadConfig()
 
 	proposalID := args[0]
 	voteOption := args[1]
+	startGasProfile()
 
 	// Validate vote option
-	validOptions := []string{"yes", "no", "abstain", "no_with_veto"}
-	isValid := false
-	for _, option := range validOptions {
-		if voteOption == option {
-			isValid = true
-			break
-		}
+	if err := junctiontest.ValidateVoteOption(voteOption); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid vote option: %s. Valid options are: %s\n", voteOption, strings.Join(junctiontest.ValidVoteOptions, ", "))
+		os.Exit(1)
 	}
 
-	if !isValid {
-		fmt.Printf("Invalid vote option: %s. Valid options are: %s\n", voteOption, strings.Join(validOptions, ", "))
-		os.Exit(1)
+	if err := junctiontest.CheckJunctiond(&config); err != nil {
+		exitWithError("Error", err)
+	}
+
+	if keys, _ := cmd.Flags().GetString("keys"); keys != "" {
+		voteFromKeys(proposalID, strings.Split(keys, ","), voteOption)
+		return
 	}
 
 	fmt.Printf("🗳️  Voting %s on proposal %s...\n", voteOption, proposalID)
 
-	voteCmd := exec.Command(
-		config.JunctiondPath,
-		"tx", "gov", "vote", proposalID, voteOption,
-		"--from", config.KeyName,
-		"--chain-id", config.ChainID,
-		"--fees", "50uamf",
-		"--keyring-backend", "os",
-		"-y",
-	)
-
-	if err := runCommand(voteCmd); err != nil {
-		fmt.Printf("Error voting on proposal: %v\n", err)
-		os.Exit(1)
+	txResponse, err := junctiontest.Vote(&config, proposalID, voteOption)
+	if err != nil {
+		exitWithError("Error voting on proposal", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if _, err := waitForTx(ctx, "vote", txResponse.TxHash); errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "\n🛑 Interrupted; vote tx %s was broadcast but not confirmed\n", txResponse.TxHash)
+		os.Exit(130)
+	} else if err != nil {
+		exitWithError("Error confirming vote", err)
 	}
 
 	fmt.Printf("✅ Successfully voted %s on proposal %s!\n", voteOption, proposalID)
+	updateState(func(state *TestingState) {
+		state.Phase = phaseVoted
+		state.ProposalID = proposalID
+	})
 }
 
-func runMonitorProposals(cmd *cobra.Command, args []string) {
-	// Load configuration
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			fmt.Printf("Error reading config file: %v\n", err)
+// voteFromKeys votes from each key in turn, reporting every key's result
+// before exiting with the first failure's code if any vote failed.
+func voteFromKeys(proposalID string, keys []string, voteOption string) {
+	for i := range keys {
+		keys[i] = strings.TrimSpace(keys[i])
+	}
+
+	var failures []error
+	results := junctiontest.VoteFromAll(&config, proposalID, keys, voteOption)
+	saveGasProfile()
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", result.Key, result.Err)
+			failures = append(failures, fmt.Errorf("%s: %w", result.Key, result.Err))
+			continue
 		}
+		fmt.Printf("✅ %s voted %s\n", result.Key, voteOption)
 	}
 
-	if err := viper.Unmarshal(&config); err != nil {
-		fmt.Printf("Error unmarshaling config: %v\n", err)
-		os.Exit(1)
+	updateState(func(state *TestingState) {
+		state.Phase = phaseVoted
+		state.ProposalID = proposalID
+	})
+	if len(failures) > 0 {
+		exitWithError("Error", fmt.Errorf("%d of %d votes failed: %w", len(failures), len(keys), failures[0]))
 	}
+	fmt.Printf("✅ All %d keys voted %s on proposal %s!\n", len(keys), voteOption, proposalID)
+}
+
+func runMonitorProposals(cmd *cobra.Command, args []string) {
+	// Load configuration
+	loadConfig()
 
 	fmt.Println("🔍 Monitoring governance proposals...")
 	fmt.Println("Press Ctrl+C to stop monitoring")
+	startMetricsServer()
+
+	spin, err := newSpinner(config.Spinner, config.SpinnerInterval)
+	if err != nil {
+		exitWithError("Error", err)
+	}
 
-	// Animation frames for different states
-	spinner := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	spinnerIndex := 0
+	// Abort rather than keep polling a chain that has gone away
+	const maxConsecutiveFailures = 6
+	failures := 0
 
 	for {
 		// Fetch proposals
-		proposals, err := fetchProposals(config.RestEndpoint)
+		proposals, err := junctiontest.FetchProposals(config.RestEndpoint)
 		if err != nil {
-			fmt.Printf("\r❌ Error fetching proposals: %v", err)
+			failures++
+			fmt.Fprintf(os.Stderr, "\r❌ Error fetching proposals: %v", err)
+			if failures >= maxConsecutiveFailures {
+				fmt.Fprintf(os.Stderr, "\nError: chain unreachable at %s for %d consecutive attempts; is junctiond still running?\n", config.RestEndpoint, failures)
+				os.Exit(exitCodes["chain_not_ready"])
+			}
 			time.Sleep(5 * time.Second)
 			continue
 		}
+		failures = 0
 
 		// Clear screen and show status
 		fmt.Print("\033[2J\033[H") // Clear screen
@@ -540,61 +861,40 @@ func runMonitorProposals(cmd *cobra.Command, args []string) {
 		fmt.Println("================================")
 
 		if len(proposals.Proposals) == 0 {
-			fmt.Printf("\r%s No proposals found", spinner[spinnerIndex%len(spinner)])
-		} else {
-			for _, proposal := range proposals.Proposals {
-				status := getStatusDisplay(proposal.Status)
-				fmt.Printf("📋 Proposal #%s - %s\n", proposal.ID, status)
-
-				if proposal.Status == "PROPOSAL_STATUS_VOTING_PERIOD" {
-					fmt.Printf("   ⏰ Voting Period: %s to %s\n",
-						formatTime(proposal.VotingStartTime),
-						formatTime(proposal.VotingEndTime))
-
-					// Check if voting period has ended
-					if isVotingPeriodEnded(proposal.VotingEndTime) {
-						fmt.Println("   🎉 VOTING PERIOD COMPLETED!")
-						showCompletionAnimation()
-						return
-					}
-				}
+			spin.show("No proposals found", 2*time.Second)
+			continue
+		}
 
-				fmt.Printf("   📊 Tally: Yes: %s, No: %s, Abstain: %s, No with Veto: %s\n",
-					proposal.FinalTallyResult.YesCount,
-					proposal.FinalTallyResult.NoCount,
-					proposal.FinalTallyResult.AbstainCount,
-					proposal.FinalTallyResult.NoWithVetoCount)
-				fmt.Println()
+		for _, proposal := range proposals.Proposals {
+			status := getStatusDisplay(proposal.Status)
+			fmt.Printf("📋 Proposal #%s - %s\n", proposal.ID, status)
+
+			if proposal.Status == "PROPOSAL_STATUS_VOTING_PERIOD" {
+				fmt.Printf("   ⏰ Voting Period: %s to %s\n",
+					formatTime(proposal.VotingStartTime),
+					formatTime(proposal.VotingEndTime))
+
+				// Check if voting period has ended
+				if isVotingPeriodEnded(proposal.VotingEndTime) {
+					fmt.Println("   🎉 VOTING PERIOD COMPLETED!")
+					showCompletionAnimation()
+					reportProposalOutcome(proposal.ID)
+					return
+				}
 			}
+
+			fmt.Printf("   📊 Tally: Yes: %s, No: %s, Abstain: %s, No with Veto: %s\n",
+				proposal.FinalTallyResult.YesCount,
+				proposal.FinalTallyResult.NoCount,
+				proposal.FinalTallyResult.AbstainCount,
+				proposal.FinalTallyResult.NoWithVetoCount)
+			fmt.Println()
 		}
 
-		spinnerIndex++
 		time.Sleep(2 * time.Second)
 	}
 }
 
-func fetchProposals(restEndpoint string) (*ProposalResponse, error) {
-	url := fmt.Sprintf("%s/cosmos/gov/v1/proposals?proposal_status=PROPOSAL_STATUS_UNSPECIFIED", restEndpoint)
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var proposalResponse ProposalResponse
-	if err := json.Unmarshal(body, &proposalResponse); err != nil {
-		return nil, err
-	}
-
-	return &proposalResponse, nil
-}
-
 func getStatusDisplay(status string) string {
 	switch status {
 	case "PROPOSAL_STATUS_DEPOSIT_PERIOD":
@@ -628,6 +928,111 @@ func isVotingPeriodEnded(votingEndTime string) bool {
 	return time.Now().After(endTime)
 }
 
+// reportProposalOutcome waits for the chain to tally the proposal, finishes
+// the flow and exits with the proposal_rejected code if it did not pass, or
+// with expectation_failed if it does not match EXPECT_STATUS or
+// EXPECT_TALLY_YES_MIN. POST_HOOK, if set, runs before the chain is stopped
+// and fails the run if it fails.
+func reportProposalOutcome(proposalID string) {
+	proposal, err := junctiontest.WaitForProposalFinal(config.RestEndpoint, proposalID, time.Minute)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not determine final status of proposal %s: %v\n", proposalID, err)
+		finishChain()
+		return
+	}
+
+	outcome := junctiontest.CheckProposalOutcome(proposal)
+	updateState(func(state *TestingState) {
+		state.Phase = phaseFinished
+		state.ProposalID = proposalID
+		state.Outcome = proposal.Status
+	})
+	if err := junctiontest.NotifyProposalWebhook(&config, junctiontest.NewProposalWebhookPayload(config.ChainID, proposal)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if outcome != nil {
+		if reason, reasonErr := junctiontest.ExtractRejectionReason(config.RestEndpoint, proposalID); reasonErr == nil {
+			fmt.Fprintf(os.Stderr, "❌ %s\n", reason)
+		}
+	} else {
+		fmt.Printf("✅ Proposal #%s passed\n", proposalID)
+		outcome = checkValidatorSet(proposalID)
+	}
+
+	// With expectations set they decide the result, so an expected
+	// rejection is a success
+	if config.ExpectStatus != "" || config.ExpectTallyYesMin > 0 {
+		if err := junctiontest.CheckProposalExpectations(proposal, config.ExpectStatus, config.ExpectTallyYesMin); err != nil {
+			outcome = err
+		} else {
+			fmt.Printf("✅ Proposal #%s matches expectations (%s, %.2f%% yes)\n", proposalID, proposal.Status, junctiontest.YesPercentage(proposal))
+			if errors.Is(outcome, junctiontest.ErrProposalRejected) {
+				outcome = nil
+			}
+		}
+	}
+
+	if config.PostHook != "" {
+		fmt.Printf("🪝 Running post hook %s...\n", config.PostHook)
+		if err := junctiontest.RunPostHook(config.PostHook, config.ChainID, proposal); err != nil {
+			if outcome == nil {
+				outcome = err
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+
+	finishChain()
+	if outcome != nil {
+		exitWithError("Error", outcome)
+	}
+}
+
+// checkValidatorSet compares the validator set against the snapshot taken
+// when proposalID was submitted. Proposals submitted elsewhere have no
+// snapshot and are skipped.
+func checkValidatorSet(proposalID string) error {
+	before, err := junctiontest.ReadValidatorSnapshot(junctiontest.ValidatorSnapshotPath(&config, proposalID))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		return nil
+	}
+	if err := junctiontest.CheckValidatorSetUnchanged(config.RPCEndpoint, before); err != nil {
+		return err
+	}
+	fmt.Println("✅ Validator set unchanged since submission")
+	return nil
+}
+
+// finishChain stops the node started by init-node once the proposal flow is
+// done, unless keep_running is set, in which case it prints its endpoints. A
+// SINGLE_PROCESS chain is always stopped, since nothing else would stop it.
+func finishChain() {
+	if config.Mode == junctiontest.ModeRemote {
+		// Not our chain to stop
+		return
+	}
+	if config.SingleProcess {
+		junctiontest.Processes.StopAll()
+		return
+	}
+	if config.KeepRunning {
+		fmt.Println("\n🟢 Chain left running (keep_running):")
+		fmt.Printf("   RPC:  %s\n", config.RPCEndpoint)
+		fmt.Printf("   REST: %s\n", config.RestEndpoint)
+		fmt.Printf("   gRPC: %s\n", config.GRPCEndpoint)
+		return
+	}
+
+	fmt.Println("\n🛑 Stopping junctiond (set KEEP_RUNNING=true to leave it up)...")
+	if err := junctiontest.StopChainFromPIDFile(&config); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not stop junctiond: %v\n", err)
+	}
+}
+
 func showCompletionAnimation() {
 	fmt.Println("\n🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉🎉")
 	fmt.Println("🎉                                               🎉")
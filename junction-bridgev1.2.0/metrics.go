@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"junction-bridge/junctiontest"
+)
+
+// startMetricsServer serves junctiontest.Metrics on /metrics in the
+// Prometheus text format if metrics_addr is configured. The server lives as
+// long as the process.
+func startMetricsServer() {
+	if config.MetricsAddr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		junctiontest.Metrics.WritePrometheus(w)
+	})
+
+	server := &http.Server{Addr: config.MetricsAddr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Warning: metrics server stopped: %v\n", err)
+		}
+	}()
+	fmt.Printf("📈 Serving metrics on http://%s/metrics\n", config.MetricsAddr)
+}
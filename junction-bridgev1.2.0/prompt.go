@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// stdinReader is shared by all prompts so input buffered for one prompt is
+// not lost to the next.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// promptString prints prompt to stderr, so it stays visible with --quiet or
+// --print-proposal-id, and reads one line from stdin. An empty answer takes
+// fallback. When stdin is closed (piped input that ran out, or no terminal
+// in CI), a final line without a newline is still used; otherwise fallback
+// is returned, or an error naming envVar if there is no fallback.
+func promptString(prompt, fallback, envVar string) (string, error) {
+	if fallback != "" {
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", prompt, fallback)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	}
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("error reading input: %v", err)
+	}
+	if line = strings.TrimSpace(line); line != "" {
+		return line, nil
+	}
+	if fallback != "" {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(os.Stderr, fallback)
+		}
+		return fallback, nil
+	}
+	if errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("no answer to %q: stdin is closed; set %s to run non-interactively", prompt, envVar)
+	}
+	return "", fmt.Errorf("no answer to %q", prompt)
+}
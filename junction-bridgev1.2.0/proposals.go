@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+var proposalsCmd = &cobra.Command{
+	Use:   "proposals",
+	Short: "List all proposals on the chain",
+	Long:  "List every governance proposal on the chain with its id, title, status and voting end time",
+	Run:   runListProposals,
+}
+
+func init() {
+	proposalsCmd.Flags().String("status", "", "Only show proposals with this status (e.g. passed, rejected, voting_period)")
+	rootCmd.AddCommand(proposalsCmd)
+}
+
+func runListProposals(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	statusFilter, _ := cmd.Flags().GetString("status")
+	proposals, err := junctiontest.QueryProposals(&config, junctiontest.NormalizeProposalStatus(statusFilter))
+	if err != nil {
+		exitWithError("Error listing proposals", err)
+	}
+
+	if len(proposals) == 0 {
+		fmt.Println("No proposals found")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tVOTING END")
+	for _, proposal := range proposals {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", proposal.ID, proposal.Title, strings.TrimPrefix(proposal.Status, "PROPOSAL_STATUS_"), formatTime(proposal.VotingEndTime))
+	}
+	w.Flush()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var relayerCmd = &cobra.Command{
+	Use:   "relayer",
+	Short: "Start an IBC relayer between two test chains",
+	Long:  "Configure hermes, create an IBC transfer channel between this chain and a second chain, and run the relayer",
+	Run:   runRelayer,
+}
+
+func init() {
+	relayerCmd.Flags().String("chain-b-config", "", "Config file describing the second chain")
+	relayerCmd.MarkFlagRequired("chain-b-config")
+
+	rootCmd.AddCommand(relayerCmd)
+}
+
+func runRelayer(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	chainBConfigPath, _ := cmd.Flags().GetString("chain-b-config")
+	chainB, err := loadChainConfig(chainBConfigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading chain B config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if config.ChainID == chainB.ChainID {
+		fmt.Fprintf(os.Stderr, "Error: both chains use chain ID %q; chain IDs must differ\n", config.ChainID)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🔗 Setting up IBC relayer between %s and %s...\n", config.ChainID, chainB.ChainID)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer junctiontest.Processes.StopAll()
+
+	if err := junctiontest.SetupRelayer(config, *chainB); err != nil {
+		junctiontest.Processes.StopAll()
+		exitWithError("Error setting up relayer", err)
+	}
+
+	fmt.Println("✅ Relayer running. Press Ctrl+C to stop")
+	<-sigChan
+}
+
+// loadChainConfig reads a second chain's config file, falling back to the
+// current configuration for any value it does not set.
+func loadChainConfig(path string) (*junctiontest.ChainConfig, error) {
+	v := viper.New()
+	for key, value := range viper.AllSettings() {
+		v.SetDefault(key, value)
+	}
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var cfg junctiontest.ChainConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"junction-bridge/junctiontest"
+)
+
+// checkRemoteNode validates the remote node before a MODE=remote run touches
+// it.
+func checkRemoteNode() {
+	fmt.Printf("🌍 Remote mode: using chain %s at %s\n", config.ChainID, config.RPCEndpoint)
+	if err := junctiontest.CheckRemoteNode(&config); err != nil {
+		exitWithError("Error", err)
+	}
+}
+
+// finishRemote votes yes on the just-submitted proposal from key_name and
+// reports when voting ends. The shared chain's voting period may be long, so
+// the outcome is left to monitor-proposals.
+func finishRemote(ctx context.Context, proposalID string) {
+	fmt.Printf("\n🗳️  Voting yes on proposal %s...\n", proposalID)
+	txResponse, err := junctiontest.Vote(&config, proposalID, "yes")
+	if err != nil {
+		exitWithError("Error voting on proposal", err)
+	}
+	_, err = waitForTx(ctx, "vote", txResponse.TxHash)
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "\n🛑 Interrupted; vote tx %s was broadcast but not confirmed\n", txResponse.TxHash)
+		os.Exit(130)
+	}
+	if err != nil {
+		exitWithError("Error confirming vote", err)
+	}
+	updateState(func(state *TestingState) { state.Phase = phaseVoted })
+	fmt.Printf("✅ Voted yes on proposal %s\n", proposalID)
+
+	if info, err := junctiontest.FetchProposal(config.RestEndpoint, proposalID); err == nil && info.VotingEndTime != "" {
+		fmt.Printf("⏰ Voting ends at %s\n", info.VotingEndTime)
+	}
+	fmt.Println("\n🎯 Next step: use 'junction-bridge monitor-proposals' to follow the proposal to its result")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+var scenarioCmd = &cobra.Command{
+	Use:   "scenario [name]",
+	Short: "Run an end-to-end chain test scenario",
+	Long:  "Run an end-to-end chain test scenario against a freshly initialized chain, or list the available scenarios",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runScenario,
+}
+
+func init() {
+	rootCmd.AddCommand(scenarioCmd)
+}
+
+func runScenario(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	if len(args) == 0 {
+		fmt.Println("📋 Available scenarios:")
+		for _, name := range junctiontest.ScenarioNames() {
+			scenario, _ := junctiontest.LookupScenario(name)
+			fmt.Printf("  %-28s %s\n", name, scenario.Description)
+		}
+		return
+	}
+
+	scenario, ok := junctiontest.LookupScenario(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown scenario %q\n", args[0])
+		os.Exit(1)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		junctiontest.Processes.StopAll()
+		os.Exit(1)
+	}()
+
+	startGasProfile()
+	fmt.Printf("🧪 Running scenario: %s\n", scenario.Name)
+	err := scenario.Run(&config)
+	if junctiontest.Processes.Has("junctiond") {
+		printSlashingSummary()
+	}
+	junctiontest.Processes.StopAll()
+	if junctiontest.Gas != nil {
+		saveGasProfile()
+		junctiontest.Gas.PrintReport()
+	}
+
+	if err != nil {
+		exitWithError(fmt.Sprintf("❌ Scenario %s FAILED", scenario.Name), err)
+	}
+	fmt.Printf("✅ Scenario %s PASSED\n", scenario.Name)
+}
+
+// printSlashingSummary lists the validator's slashes, if any happened during
+// the run. Lookup failures are only warnings.
+func printSlashingSummary() {
+	address, err := junctiontest.ValidatorConsAddress(&config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	history, err := junctiontest.QuerySlashingHistory(&config, address)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	if len(history) == 0 {
+		return
+	}
+
+	fmt.Printf("\n⚔️  Slashing history for %s:\n", address)
+	for _, event := range history {
+		fmt.Printf("   Block %d: %s, slashed %s, jailed until %s\n",
+			event.Height, event.Reason, event.SlashFactor, event.JailedUntil.Format("2006-01-02 15:04:05"))
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+var searchProposalsCmd = &cobra.Command{
+	Use:   "search-proposals [keyword]",
+	Short: "Search proposals by keyword",
+	Long:  "Search proposal titles, summaries and IPFS metadata details for a keyword (case-insensitive)",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSearchProposals,
+}
+
+func init() {
+	rootCmd.AddCommand(searchProposalsCmd)
+}
+
+func runSearchProposals(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	keyword := args[0]
+	fmt.Printf("🔍 Searching proposals for %q...\n", keyword)
+
+	matches, err := junctiontest.SearchProposalsByKeyword(config.RestEndpoint, config.IPFSGatewayList(), config.IPFSTimeout, keyword)
+	if err != nil {
+		exitWithError("Error searching proposals", err)
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No matching proposals found")
+		return
+	}
+	for _, proposal := range matches {
+		fmt.Printf("📋 Proposal #%s - %s\n", proposal.ID, getStatusDisplay(proposal.Status))
+		fmt.Printf("   %s\n", proposal.Title)
+	}
+}
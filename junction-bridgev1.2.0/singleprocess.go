@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"junction-bridge/junctiontest"
+)
+
+// startSingleProcessChain sets up and starts the chain in the background of
+// this process (SINGLE_PROCESS), so submit-proposal needs no separate
+// init-node terminal. The chain is always stopped at the end of the flow.
+func startSingleProcessChain() {
+	fmt.Println("🧩 Single-process mode: starting the chain in the background...")
+	updateState(func(state *TestingState) {
+		*state = TestingState{ChainID: config.ChainID, Phase: phaseInitializing}
+	})
+	if err := junctiontest.SetupChain(&config); err != nil {
+		exitWithError("Error", err)
+	}
+	if err := junctiontest.StartChainBackground(&config); err != nil {
+		exitWithError("Error", err)
+	}
+	updateState(func(state *TestingState) { state.Phase = phaseChainRunning })
+}
+
+// finishSingleProcess votes yes on proposalID from key_name, waits for the
+// voting period and reports the outcome, which stops the chain started by
+// startSingleProcessChain (see finishChain).
+func finishSingleProcess(ctx context.Context, proposalID string) {
+	fmt.Printf("\n🗳️  Voting yes on proposal %s...\n", proposalID)
+	txResponse, err := junctiontest.Vote(&config, proposalID, "yes")
+	if err != nil {
+		exitWithError("Error voting on proposal", err)
+	}
+	if _, err := waitForTx(ctx, "vote", txResponse.TxHash); err != nil {
+		exitWithError("Error confirming vote", err)
+	}
+	updateState(func(state *TestingState) { state.Phase = phaseVoted })
+
+	info, err := junctiontest.FetchProposal(config.RestEndpoint, proposalID)
+	if err != nil {
+		exitWithError("Error", fmt.Errorf("error fetching proposal %s: %v", proposalID, err))
+	}
+	if err := junctiontest.WaitForVotingPeriod(ctx, &config, info); errors.Is(err, context.Canceled) {
+		junctiontest.Processes.StopAll()
+		fmt.Fprintf(os.Stderr, "\n🛑 Interrupted while waiting for proposal %s\n", proposalID)
+		os.Exit(130)
+	} else if err != nil {
+		exitWithError("Error", err)
+	}
+
+	reportProposalOutcome(proposalID)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot [name]",
+	Short: "Save the chain data directory as a named snapshot",
+	Long:  "Archive the stopped chain's home directory so it can be restored later with restore",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSnapshot,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [name]",
+	Short: "Restore the chain data directory from a named snapshot",
+	Long:  "Replace the stopped chain's home directory with a snapshot taken by snapshot",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	name := args[0]
+	fmt.Printf("📸 Saving %s as snapshot %q...\n", config.Home(), name)
+	if err := junctiontest.CreateSnapshot(&config, name); err != nil {
+		exitWithError("Error creating snapshot", err)
+	}
+	fmt.Printf("✅ Snapshot saved to %s\n", junctiontest.SnapshotPath(&config, name))
+}
+
+func runRestore(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	name := args[0]
+	fmt.Printf("📂 Restoring snapshot %q to %s...\n", name, config.Home())
+	if err := junctiontest.RestoreSnapshot(&config, name); err != nil {
+		exitWithError("Error restoring snapshot", err)
+	}
+	fmt.Println("✅ Snapshot restored. Start the node with: junctiond start")
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// spinnerFrames are the SPINNER styles. "dots" uses braille characters,
+// which some fonts render as boxes; "ascii" and "line" avoid them.
+var spinnerFrames = map[string][]string{
+	"dots":  {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	"ascii": {".  ", ".. ", "...", " ..", "  .", "   "},
+	"line":  {"-", "\\", "|", "/"},
+}
+
+// spinner animates a status line in the style chosen by SPINNER, or prints
+// it once when the style is "off".
+type spinner struct {
+	frames   []string
+	interval time.Duration
+	index    int
+}
+
+// newSpinner returns the spinner for style ("off", "dots", "ascii" or
+// "line"), advancing every interval.
+func newSpinner(style string, interval time.Duration) (*spinner, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid spinner_interval %s (must be positive)", interval)
+	}
+	if style == "off" {
+		return &spinner{interval: interval}, nil
+	}
+	frames, ok := spinnerFrames[style]
+	if !ok {
+		return nil, fmt.Errorf("invalid spinner %q (expected off, dots, ascii or line)", style)
+	}
+	return &spinner{frames: frames, interval: interval}, nil
+}
+
+// show redraws message with the next frame every interval until d has
+// passed.
+func (s *spinner) show(message string, d time.Duration) {
+	if s.frames == nil {
+		fmt.Printf("\r%s", message)
+		time.Sleep(d)
+		return
+	}
+	for deadline := time.Now().Add(d); time.Now().Before(deadline); {
+		fmt.Printf("\r%s %s", s.frames[s.index%len(s.frames)], message)
+		s.index++
+		time.Sleep(s.interval)
+	}
+}
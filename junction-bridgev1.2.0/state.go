@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"junction-bridge/junctiontest"
+)
+
+// legacyStateFile is the unkeyed state file written before state was kept
+// per chain. loadState migrates it to the keyed name on first use.
+const legacyStateFile = "testing_state.json"
+
+// Phases of the testing flow recorded in TestingState.
+const (
+	phaseInitializing      = "initializing"
+	phaseChainRunning      = "chain_running"
+	phaseChainStopped      = "chain_stopped"
+	phaseProposalSubmitted = "proposal_submitted"
+	phaseVoted             = "voted"
+	phaseFinished          = "finished"
+)
+
+// TestingState is the progress of the current testing flow.
+type TestingState struct {
+	ChainID    string    `json:"chain_id"`
+	Phase      string    `json:"phase"`
+	ProposalID string    `json:"proposal_id,omitempty"`
+	Outcome    string    `json:"outcome,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	// ExtraAccounts are the keys funded at genesis from extra_accounts,
+	// recorded so they can be cleaned up from the keyring afterwards.
+	ExtraAccounts []string `json:"extra_accounts,omitempty"`
+}
+
+// stateFilePath is where the init/submit/vote/monitor flow for chainID
+// records its progress, so separate invocations (and the status server) can
+// see each other's progress without runs against other chains clobbering it.
+func stateFilePath(chainID string) string {
+	return fmt.Sprintf("testing_state_%s.json", chainID)
+}
+
+// metadataFilePath and proposalFilePath are the per-chain files
+// submit-proposal writes to output_dir.
+func metadataFilePath() string {
+	return filepath.Join(config.OutputDir, fmt.Sprintf("metadata_%s.json", config.ChainID))
+}
+
+func proposalFilePath() string {
+	return filepath.Join(config.OutputDir, fmt.Sprintf("proposal_%s.json", config.ChainID))
+}
+
+// errStateCorrupt means a state file exists but is not valid state JSON.
+var errStateCorrupt = errors.New("corrupt testing state")
+
+// loadState reads the state file for chainID. A missing file yields a fresh
+// state for chainID; an unreadable or corrupt one is an error, leaving the
+// caller to decide whether to start fresh (see quarantineState) or abort.
+func loadState(chainID string) (*TestingState, error) {
+	migrateLegacyState(chainID)
+
+	path := stateFilePath(chainID)
+	state := &TestingState{ChainID: chainID}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errStateCorrupt, path, err)
+	}
+	return state, nil
+}
+
+// quarantineState moves chainID's unusable state file to <file>.corrupt, so
+// starting from a fresh state cannot overwrite what is left of it.
+func quarantineState(chainID string) {
+	path := stateFilePath(chainID)
+	backup := path + ".corrupt"
+	if err := os.Rename(path, backup); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not move %s aside: %v\n", path, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: moved %s to %s and starting from a fresh state\n", path, backup)
+}
+
+// migrateLegacyState renames a legacy testing_state.json to chainID's state
+// file if it belongs to chainID and no keyed file exists yet.
+func migrateLegacyState(chainID string) {
+	path := stateFilePath(chainID)
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	content, err := os.ReadFile(legacyStateFile)
+	if err != nil {
+		return
+	}
+	var legacy TestingState
+	if err := json.Unmarshal(content, &legacy); err != nil || (legacy.ChainID != "" && legacy.ChainID != chainID) {
+		return
+	}
+	if err := os.Rename(legacyStateFile, path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not migrate %s to %s: %v\n", legacyStateFile, path, err)
+		return
+	}
+	fmt.Printf("📦 Migrated %s to %s\n", legacyStateFile, path)
+}
+
+// saveState writes state to the state file for chainID.
+func saveState(chainID string, state *TestingState) error {
+	path := stateFilePath(chainID)
+	state.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling testing state: %v", err)
+	}
+	if err := junctiontest.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
+
+// updateState applies update to the saved state of the configured chain. A
+// corrupt state file is moved aside and replaced by a fresh state; one that
+// cannot be read is left alone. Failures are only warnings since the state
+// is informational.
+func updateState(update func(state *TestingState)) {
+	state, err := loadState(config.ChainID)
+	if errors.Is(err, errStateCorrupt) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		quarantineState(config.ChainID)
+		state = &TestingState{ChainID: config.ChainID}
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not updating testing state: %v\n", err)
+		return
+	}
+	update(state)
+	junctiontest.Metrics.SetPhase(state.Phase)
+	if err := saveState(config.ChainID, state); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+// chdirTemp runs the rest of the test in a fresh temporary directory, since
+// the state files live in the working directory.
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func writeTestState(t *testing.T, path string, state TestingState) {
+	t.Helper()
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadState(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantPhase string
+		wantErr   error
+	}{
+		{"missing file", "", "", nil},
+		{"saved state", `{"chain_id":"junction","phase":"voted","proposal_id":"3"}`, phaseVoted, nil},
+		{"corrupt file", `{"chain_id":`, "", errStateCorrupt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			if tt.content != "" {
+				if err := os.WriteFile(stateFilePath("junction"), []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			state, err := loadState("junction")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("loadState() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadState() error = %v", err)
+			}
+			if state.ChainID != "junction" || state.Phase != tt.wantPhase {
+				t.Errorf("loadState() = %+v, want chain junction in phase %q", state, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestMigrateLegacyState(t *testing.T) {
+	tests := []struct {
+		name         string
+		legacy       *TestingState
+		keyed        bool
+		wantMigrated bool
+	}{
+		{"legacy state for the chain", &TestingState{ChainID: "junction", Phase: phaseVoted}, false, true},
+		{"legacy state without chain id", &TestingState{Phase: phaseVoted}, false, true},
+		{"legacy state for another chain", &TestingState{ChainID: "other", Phase: phaseVoted}, false, false},
+		{"keyed state already exists", &TestingState{ChainID: "junction", Phase: phaseVoted}, true, false},
+		{"no legacy state", nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			if tt.legacy != nil {
+				writeTestState(t, legacyStateFile, *tt.legacy)
+			}
+			if tt.keyed {
+				writeTestState(t, stateFilePath("junction"), TestingState{ChainID: "junction", Phase: phaseInitializing})
+			}
+
+			migrateLegacyState("junction")
+
+			_, legacyErr := os.Stat(legacyStateFile)
+			legacyGone := os.IsNotExist(legacyErr)
+			if tt.legacy != nil && legacyGone != tt.wantMigrated {
+				t.Errorf("legacy file removed = %v, want %v", legacyGone, tt.wantMigrated)
+			}
+			state, err := loadState("junction")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if migrated := state.Phase == phaseVoted; migrated != tt.wantMigrated {
+				t.Errorf("keyed state phase = %q, migrated = %v, want %v", state.Phase, migrated, tt.wantMigrated)
+			}
+		})
+	}
+}
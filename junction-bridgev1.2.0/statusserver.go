@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"junction-bridge/junctiontest"
+)
+
+// statusServer serves /state and /healthz when http_addr is set.
+var statusServer *http.Server
+
+// startStatusServer starts the status server in the background if http_addr
+// is configured. /state returns the TestingState JSON and /healthz returns
+// 200 once the node is synced, 503 before that.
+func startStatusServer() {
+	if config.HTTPAddr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/state", func(w http.ResponseWriter, r *http.Request) {
+		state, err := loadState(config.ChainID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(state)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		state, err := junctiontest.QuerySyncState(config.RPCEndpoint)
+		if err != nil || state != junctiontest.SyncStateSynced {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "chain not ready: %s\n", state)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+
+	statusServer = &http.Server{Addr: config.HTTPAddr, Handler: mux}
+	go func() {
+		if err := statusServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Warning: status server stopped: %v\n", err)
+		}
+	}()
+	fmt.Printf("📡 Serving status on http://%s (/state, /healthz)\n", config.HTTPAddr)
+}
+
+// stopStatusServer shuts the status server down, if one was started.
+func stopStatusServer() {
+	if statusServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := statusServer.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: error stopping status server: %v\n", err)
+	}
+}
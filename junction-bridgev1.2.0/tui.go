@@ -0,0 +1,435 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tuiLogLines is how many lines of the chain log the TUI shows.
+const tuiLogLines = 8
+
+// Phases of the TUI flow, shown as a checklist.
+const (
+	tuiPhaseSync = iota
+	tuiPhaseMetadata
+	tuiPhaseForm
+	tuiPhaseSubmit
+	tuiPhaseVote
+	tuiPhaseVoting
+	tuiPhaseOutcome
+)
+
+var tuiPhaseNames = []string{
+	"Node synced and proposer funded",
+	"metadata.json written",
+	"Proposal details",
+	"Proposal submitted",
+	"Voted yes",
+	"Voting period",
+	"Outcome",
+}
+
+// Messages sent by the TUI's background steps.
+type (
+	tuiTickMsg    time.Time
+	tuiPhaseMsg   struct{ phase int }
+	tuiErrMsg     struct{ err error }
+	tuiSubmitMsg  struct{ proposalID string }
+	tuiVotingMsg  struct{ start, end time.Time }
+	tuiOutcomeMsg struct{ info *junctiontest.ProposalInfo }
+)
+
+// tuiModel drives the submit, vote and wait flow as a bubbletea program.
+type tuiModel struct {
+	phase      int
+	err        error
+	inputs     []textinput.Model
+	focus      int
+	metadata   string
+	proposalID string
+	votingFrom time.Time
+	votingTo   time.Time
+	outcome    string
+	bar        progress.Model
+	logLines   []string
+	ctx        context.Context
+}
+
+// runSubmitTUI runs submit-proposal as a full-screen TUI (TUI=1): a phase
+// checklist, a form for the bridge workers, contract and metadata CID, the
+// tail of the chain log and a voting countdown. Output from the underlying
+// steps goes to tui.log in the output directory.
+func runSubmitTUI() {
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		exitWithError("Error", err)
+	}
+	logPath := filepath.Join(config.OutputDir, "tui.log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		exitWithError("Error", err)
+	}
+	defer logFile.Close()
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = logFile, logFile
+	// Quitting cancels any wait still running behind the UI
+	ctx, cancel := context.WithCancel(context.Background())
+	program := tea.NewProgram(newTUIModel(ctx), tea.WithAltScreen(), tea.WithOutput(originalStdout))
+	final, err := program.Run()
+	cancel()
+	os.Stdout, os.Stderr = stdout, stderr
+	if err != nil {
+		exitWithError("Error running TUI", err)
+	}
+
+	model := final.(tuiModel)
+	if model.err != nil {
+		fmt.Fprintf(os.Stderr, "See %s for details\n", logPath)
+		exitWithError("Error", model.err)
+	}
+	if model.phase < tuiPhaseOutcome {
+		fmt.Fprintln(os.Stderr, "Aborted")
+		os.Exit(1)
+	}
+	fmt.Printf("Proposal %s: %s\n", model.proposalID, model.outcome)
+}
+
+func newTUIModel(ctx context.Context) tuiModel {
+	defaults := junctiontest.NewBridgeProposal("", config.Expedited).Messages[0].Params
+	fields := []struct{ placeholder, value string }{
+		{"Bridge workers (comma-separated)", strings.Join(defaults.BridgeWorkers, ",")},
+		{"Bridge contract address", defaults.BridgeContractAddress},
+		{"IPFS CID of metadata.json", ""},
+	}
+
+	inputs := make([]textinput.Model, len(fields))
+	for i, field := range fields {
+		inputs[i] = textinput.New()
+		inputs[i].Placeholder = field.placeholder
+		inputs[i].SetValue(field.value)
+		inputs[i].Width = 60
+	}
+	inputs[0].Focus()
+
+	return tuiModel{inputs: inputs, bar: progress.New(progress.WithDefaultGradient()), ctx: ctx}
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return tea.Batch(tuiTick(), tuiSyncStep())
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "q":
+			if m.err != nil || m.phase == tuiPhaseOutcome {
+				return m, tea.Quit
+			}
+		}
+		if m.phase == tuiPhaseForm && m.err == nil {
+			return m.updateForm(msg)
+		}
+
+	case tea.WindowSizeMsg:
+		m.bar.Width = msg.Width - 10
+
+	case tuiTickMsg:
+		m.logLines = tailFile(junctiontest.ChainLogPath(&config), tuiLogLines)
+		return m, tuiTick()
+
+	case tuiErrMsg:
+		m.err = msg.err
+
+	case tuiPhaseMsg:
+		m.phase = msg.phase
+		if m.phase == tuiPhaseMetadata {
+			m.metadata = metadataFilePath()
+			return m, tuiMetadataStep(m.metadata)
+		}
+
+	case tuiSubmitMsg:
+		m.proposalID = msg.proposalID
+		m.phase = tuiPhaseVote
+		return m, tuiVoteStep(m.proposalID)
+
+	case tuiVotingMsg:
+		m.votingFrom, m.votingTo = msg.start, msg.end
+		m.phase = tuiPhaseVoting
+		return m, tuiWaitStep(m.ctx, m.proposalID)
+
+	case tuiOutcomeMsg:
+		m.phase = tuiPhaseOutcome
+		m.outcome = msg.info.Status
+		if err := junctiontest.CheckProposalOutcome(msg.info); err != nil {
+			m.err = err
+		}
+	}
+	return m, nil
+}
+
+// updateForm handles keys while the proposal details form is shown.
+func (m tuiModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "down":
+		m.focus = (m.focus + 1) % len(m.inputs)
+	case "shift+tab", "up":
+		m.focus = (m.focus + len(m.inputs) - 1) % len(m.inputs)
+	case "enter":
+		if m.focus < len(m.inputs)-1 {
+			m.focus++
+			break
+		}
+		cid := strings.TrimSpace(m.inputs[2].Value())
+		if cid == "" {
+			break
+		}
+		m.phase = tuiPhaseSubmit
+		return m, tuiSubmitStep(m.inputs[0].Value(), m.inputs[1].Value(), cid)
+	default:
+		var cmd tea.Cmd
+		m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+		return m, cmd
+	}
+
+	for i := range m.inputs {
+		if i == m.focus {
+			m.inputs[i].Focus()
+		} else {
+			m.inputs[i].Blur()
+		}
+	}
+	return m, nil
+}
+
+func (m tuiModel) View() string {
+	var b strings.Builder
+	b.WriteString("🗳️  Junction Bridge Proposal\n\n")
+
+	for i, name := range tuiPhaseNames {
+		mark := "[ ]"
+		switch {
+		case i < m.phase || (i == m.phase && i == tuiPhaseOutcome):
+			mark = "[✓]"
+		case i == m.phase && m.err != nil:
+			mark = "[✗]"
+		case i == m.phase:
+			mark = "[…]"
+		}
+		if i == tuiPhaseOutcome && m.outcome != "" {
+			name += ": " + m.outcome
+		}
+		if i == tuiPhaseSubmit && m.proposalID != "" {
+			name += " (#" + m.proposalID + ")"
+		}
+		fmt.Fprintf(&b, "  %s %s\n", mark, name)
+	}
+	b.WriteString("\n")
+
+	if m.phase == tuiPhaseForm && m.err == nil {
+		fmt.Fprintf(&b, "Upload %s to IPFS, then fill in the details (tab to move, enter to submit):\n\n", m.metadata)
+		for _, input := range m.inputs {
+			fmt.Fprintf(&b, "  %s\n", input.View())
+		}
+		b.WriteString("\n")
+	}
+
+	if m.phase == tuiPhaseVoting && !m.votingTo.IsZero() {
+		total := m.votingTo.Sub(m.votingFrom)
+		remaining := time.Until(m.votingTo)
+		if remaining < 0 {
+			remaining = 0
+		}
+		percent := 1.0
+		if total > 0 {
+			percent = 1 - float64(remaining)/float64(total)
+		}
+		fmt.Fprintf(&b, "Voting ends in %s\n%s\n\n", remaining.Round(time.Second), m.bar.ViewAs(percent))
+	}
+
+	if m.err != nil {
+		fmt.Fprintf(&b, "❌ %v\n\n", m.err)
+	}
+
+	fmt.Fprintf(&b, "── %s ──\n", junctiontest.ChainLogPath(&config))
+	for _, line := range m.logLines {
+		if len(line) > 120 {
+			line = line[:120]
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if m.err != nil || m.phase == tuiPhaseOutcome {
+		b.WriteString("\nPress q to exit\n")
+	} else {
+		b.WriteString("\nPress ctrl+c to abort\n")
+	}
+	return b.String()
+}
+
+func tuiTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tuiTickMsg(t) })
+}
+
+// tuiSyncStep runs the same preflight checks as the plain flow.
+func tuiSyncStep() tea.Cmd {
+	return func() tea.Msg {
+		if err := junctiontest.CheckJunctiond(&config); err != nil {
+			return tuiErrMsg{err}
+		}
+		if err := junctiontest.WaitForSync(config.RPCEndpoint, config.SyncTimeout, config.ReadyBackoff()); err != nil {
+			return tuiErrMsg{err}
+		}
+		if err := junctiontest.CheckProposerBalance(&config, junctiontest.DefaultProposalDeposit); err != nil {
+			return tuiErrMsg{err}
+		}
+		return tuiPhaseMsg{tuiPhaseMetadata}
+	}
+}
+
+func tuiMetadataStep(path string) tea.Cmd {
+	return func() tea.Msg {
+		proposal := junctiontest.NewBridgeProposal("", config.Expedited)
+		metadata, err := junctiontest.BuildMetadata(&config, &proposal)
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		if err := junctiontest.WriteMetadataFile(path, metadata); err != nil {
+			return tuiErrMsg{err}
+		}
+		return tuiPhaseMsg{tuiPhaseForm}
+	}
+}
+
+func tuiSubmitStep(workers, contract, cid string) tea.Cmd {
+	return func() tea.Msg {
+		metadata, err := junctiontest.ReadMetadataFile(metadataFilePath())
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		if err := junctiontest.VerifyLocalCID(metadataFilePath(), cid); err != nil && !errors.Is(err, junctiontest.ErrCIDNotVerifiable) {
+			return tuiErrMsg{err}
+		}
+		proposal := junctiontest.NewBridgeProposal("ipfs://"+cid, config.Expedited)
+		junctiontest.SyncProposalText(&proposal, metadata)
+		params := &proposal.Messages[0].Params
+		params.BridgeWorkers = nil
+		for _, worker := range strings.Split(workers, ",") {
+			if worker = strings.TrimSpace(worker); worker != "" {
+				params.BridgeWorkers = append(params.BridgeWorkers, worker)
+			}
+		}
+		params.BridgeContractAddress = strings.TrimSpace(contract)
+		if config.ProposalMessagesFile != "" {
+			if proposal.RawMessages, err = junctiontest.ReadProposalMessagesFile(config.ProposalMessagesFile); err != nil {
+				return tuiErrMsg{err}
+			}
+		}
+
+		txResponse, err := junctiontest.SubmitProposal(&config, proposal, proposalFilePath())
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		result, err := waitForTx(context.Background(), "submit", txResponse.TxHash)
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		proposalID, err := junctiontest.ProposalIDFromTx(result)
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		updateState(func(state *TestingState) {
+			state.Phase = phaseProposalSubmitted
+			state.ProposalID = proposalID
+			state.Outcome = ""
+		})
+		return tuiSubmitMsg{proposalID}
+	}
+}
+
+func tuiVoteStep(proposalID string) tea.Cmd {
+	return func() tea.Msg {
+		txResponse, err := junctiontest.Vote(&config, proposalID, "yes")
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		if _, err := waitForTx(context.Background(), "vote", txResponse.TxHash); err != nil {
+			return tuiErrMsg{err}
+		}
+		updateState(func(state *TestingState) { state.Phase = phaseVoted })
+
+		info, err := junctiontest.FetchProposal(config.RestEndpoint, proposalID)
+		if err != nil {
+			return tuiErrMsg{fmt.Errorf("error fetching proposal %s: %v", proposalID, err)}
+		}
+		start, _ := time.Parse(time.RFC3339Nano, info.VotingStartTime)
+		end, err := time.Parse(time.RFC3339Nano, info.VotingEndTime)
+		if err != nil {
+			return tuiErrMsg{fmt.Errorf("proposal %s is not in the voting period (%s)", proposalID, info.Status)}
+		}
+		return tuiVotingMsg{start, end}
+	}
+}
+
+func tuiWaitStep(ctx context.Context, proposalID string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := junctiontest.FetchProposal(config.RestEndpoint, proposalID)
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		if err := junctiontest.WaitForVotingPeriod(ctx, &config, info); err != nil {
+			return tuiErrMsg{err}
+		}
+		info, err = junctiontest.WaitForProposalFinal(config.RestEndpoint, proposalID, 2*time.Minute)
+		if err != nil {
+			return tuiErrMsg{err}
+		}
+		updateState(func(state *TestingState) {
+			state.Phase = phaseFinished
+			state.Outcome = info.Status
+		})
+		if err := junctiontest.NotifyProposalWebhook(&config, junctiontest.NewProposalWebhookPayload(config.ChainID, info)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		return tuiOutcomeMsg{info}
+	}
+}
+
+// tailFile returns up to n trailing lines of path, or nil if it cannot be
+// read.
+func tailFile(path string, n int) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	const maxTail = 16 * 1024
+	if info, err := file.Stat(); err == nil && info.Size() > maxTail {
+		file.Seek(info.Size()-maxTail, io.SeekStart)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"junction-bridge/junctiontest"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [proposal.json] [metadata.json]",
+	Short: "Check proposal and metadata files without a chain",
+	Long:  "Run the structural and semantic checks on a proposal file and its metadata file (default: this chain's proposal_<chain_id>.json and metadata_<chain_id>.json) and print a pass/fail report",
+	Args:  cobra.MaximumNArgs(2),
+	Run:   runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, args []string) {
+	loadConfig()
+
+	proposalPath, metadataPath := proposalFilePath(), metadataFilePath()
+	if len(args) > 0 {
+		proposalPath = args[0]
+	}
+	if len(args) > 1 {
+		metadataPath = args[1]
+	}
+
+	fmt.Printf("🔎 Validating %s and %s...\n", proposalPath, metadataPath)
+	failed := 0
+	for _, check := range junctiontest.ValidateProposalFiles(proposalPath, metadataPath) {
+		if check.Err != nil {
+			failed++
+			fmt.Printf("❌ %s: %v\n", check.Name, check.Err)
+			continue
+		}
+		fmt.Printf("✅ %s\n", check.Name)
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "\n%d check(s) failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("\n✅ All checks passed")
+}